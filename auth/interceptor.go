@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strconv"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Policy declares who may call a method
+type Policy struct {
+	// Public methods are served without resolving the caller
+	Public bool
+	// Roles allowed to call the method, checked by auth-service. Any
+	// signed-in user may call it when empty.
+	Roles []pb.UserRole
+	// Internal methods are only served to other services of the platform,
+	// which present the shared service token besides the user's credentials
+	Internal bool
+}
+
+// ServiceTokenKey is the metadata key carrying the service token of internal
+// calls
+const ServiceTokenKey = "x-service-token"
+
+// Interceptor resolves the caller once per request and enforces the policy of
+// the called method. Methods missing from the policy table are rejected.
+type Interceptor struct {
+	authClient   pb.AuthServiceClient
+	userClient   pb.UserServiceClient
+	policies     map[string]Policy
+	serviceToken string
+}
+
+// NewInterceptor ... Internal methods are refused when serviceToken is empty.
+func NewInterceptor(authClient pb.AuthServiceClient, userClient pb.UserServiceClient, policies map[string]Policy, serviceToken string) *Interceptor {
+	return &Interceptor{
+		authClient:   authClient,
+		userClient:   userClient,
+		policies:     policies,
+		serviceToken: serviceToken,
+	}
+}
+
+// Unary ...
+func (interceptor *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := interceptor.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream ...
+func (interceptor *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := interceptor.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize forwards the caller's credentials to downstream services and puts
+// the resolved Principal on the context
+func (interceptor *Interceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	policy, ok := interceptor.policies[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no access policy for %s", method)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if policy.Internal && !interceptor.internalCaller(md) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is internal", method)
+	}
+	if ok {
+		// the service token is meant for this service only
+		md = md.Copy()
+		md.Delete(ServiceTokenKey)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	if policy.Public {
+		return ctx, nil
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "can't parse context")
+	}
+
+	var principal Principal
+	var err error
+	if len(policy.Roles) == 0 {
+		principal, err = interceptor.resolve(ctx)
+	} else {
+		principal, err = interceptor.authorizeRoles(ctx, policy.Roles)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContext(ctx, principal), nil
+}
+
+// internalCaller reports whether md carries the service token
+func (interceptor *Interceptor) internalCaller(md metadata.MD) bool {
+	if interceptor.serviceToken == "" {
+		return false
+	}
+	tokens := md.Get(ServiceTokenKey)
+	if len(tokens) != 1 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(interceptor.serviceToken)) == 1
+}
+
+// resolve looks up any signed-in caller
+func (interceptor *Interceptor) resolve(ctx context.Context) (Principal, error) {
+	me, err := interceptor.userClient.GetMe(ctx, &emptypb.Empty{})
+	if err != nil {
+		return Principal{}, err
+	}
+
+	return Principal{
+		ID:   me.GetId(),
+		Role: me.GetRole(),
+	}, nil
+}
+
+// authorizeRoles lets auth-service check the caller's token against each
+// allowed role in turn. The caller is unauthenticated only when every check
+// says so, otherwise a refused check means a missing role. Any other failure,
+// such as auth-service being down, is returned as is.
+func (interceptor *Interceptor) authorizeRoles(ctx context.Context, roles []pb.UserRole) (Principal, error) {
+	unauthenticated := true
+	for _, role := range roles {
+		claims, err := interceptor.authorizeRole(ctx, role)
+		if err != nil {
+			code := status.Code(err)
+			if code != codes.Unauthenticated && code != codes.PermissionDenied {
+				return Principal{}, err
+			}
+			unauthenticated = unauthenticated && code == codes.Unauthenticated
+			continue
+		}
+
+		id, err := strconv.ParseInt(claims.GetId(), 10, 64)
+		if err != nil {
+			return Principal{}, status.Error(codes.Unauthenticated, "invalid user claims")
+		}
+
+		return Principal{
+			ID:   id,
+			Role: claims.GetUserRole(),
+		}, nil
+	}
+
+	if unauthenticated {
+		return Principal{}, status.Error(codes.Unauthenticated, "Vui lòng đăng nhập")
+	}
+	return Principal{}, status.Error(codes.PermissionDenied, "Bạn không có quyền thực hiện thao tác này")
+}
+
+func (interceptor *Interceptor) authorizeRole(ctx context.Context, role pb.UserRole) (*pb.UserClaimsResponse, error) {
+	switch role {
+	case pb.UserRole_customer:
+		return interceptor.authClient.CustomerAuthorization(ctx, &emptypb.Empty{})
+	case pb.UserRole_supplier:
+		return interceptor.authClient.SupplierAuthorization(ctx, &emptypb.Empty{})
+	case pb.UserRole_admin:
+		return interceptor.authClient.AdminAuthorization(ctx, &emptypb.Empty{})
+	}
+
+	return nil, status.Errorf(codes.PermissionDenied, "unknown role %v", role)
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	ID   int64
+	Role pb.UserRole
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying p
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller resolved by the interceptor, or an
+// Unauthenticated error for public methods called anonymously
+func FromContext(ctx context.Context) (Principal, error) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok {
+		return Principal{}, status.Error(codes.Unauthenticated, "Vui lòng đăng nhập")
+	}
+
+	return p, nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shop_follower;
\ No newline at end of file
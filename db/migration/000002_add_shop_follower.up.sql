@@ -0,0 +1,9 @@
+CREATE TABLE shop_follower (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "user_id" int8 NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    UNIQUE ("shop_id", "user_id")
+);
+
+CREATE INDEX ON shop_follower ("user_id", "id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shop_registration_saga;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE shop_registration_saga (
+    "id" serial8 PRIMARY KEY,
+    "seller_id" int8 NOT NULL,
+    "name" varchar(64) NOT NULL,
+    "avatar" varchar(256),
+    "state" varchar(16) NOT NULL DEFAULT 'pending'
+        CHECK ("state" IN ('pending', 'role_granted', 'shop_created', 'done', 'compensating', 'failed')),
+    "attempts" int4 NOT NULL DEFAULT 0,
+    "last_error" text,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- a seller can only have one registration in flight
+CREATE UNIQUE INDEX ON shop_registration_saga ("seller_id") WHERE "state" NOT IN ('done', 'failed');
+CREATE INDEX ON shop_registration_saga ("state", "updated_at");
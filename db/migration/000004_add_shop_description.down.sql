@@ -0,0 +1 @@
+ALTER TABLE shop DROP COLUMN IF EXISTS "description";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE shop ADD COLUMN "description" text NOT NULL DEFAULT '';
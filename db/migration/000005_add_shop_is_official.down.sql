@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS shop_is_official_idx;
+ALTER TABLE shop DROP COLUMN IF EXISTS "is_official";
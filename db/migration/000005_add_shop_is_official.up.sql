@@ -0,0 +1,4 @@
+ALTER TABLE shop ADD COLUMN "is_official" boolean NOT NULL DEFAULT false;
+
+-- only one shop can be the platform-owned official store
+CREATE UNIQUE INDEX shop_is_official_idx ON shop ("is_official") WHERE "is_official";
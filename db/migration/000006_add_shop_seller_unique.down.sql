@@ -0,0 +1 @@
+ALTER TABLE shop DROP CONSTRAINT IF EXISTS shop_seller_id_key;
\ No newline at end of file
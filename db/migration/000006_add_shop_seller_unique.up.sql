@@ -0,0 +1,11 @@
+-- sellers that registered more than once must have their shops merged by
+-- hand first, deleting the extra shops would take their followers with them
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM shop GROUP BY "seller_id" HAVING count(*) > 1) THEN
+        RAISE EXCEPTION 'some sellers own more than one shop, merge them before adding shop_seller_id_key';
+    END IF;
+END
+$$;
+
+ALTER TABLE shop ADD CONSTRAINT shop_seller_id_key UNIQUE ("seller_id");
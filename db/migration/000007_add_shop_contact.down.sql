@@ -0,0 +1,5 @@
+ALTER TABLE shop
+    DROP COLUMN IF EXISTS "banner",
+    DROP COLUMN IF EXISTS "contact_phone",
+    DROP COLUMN IF EXISTS "contact_email",
+    DROP COLUMN IF EXISTS "address";
@@ -0,0 +1,5 @@
+ALTER TABLE shop
+    ADD COLUMN "banner" varchar(256) NOT NULL DEFAULT '',
+    ADD COLUMN "contact_phone" varchar(16) NOT NULL DEFAULT '',
+    ADD COLUMN "contact_email" varchar(128) NOT NULL DEFAULT '',
+    ADD COLUMN "address" varchar(256) NOT NULL DEFAULT '';
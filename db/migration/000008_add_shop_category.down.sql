@@ -0,0 +1,2 @@
+ALTER TABLE shop_registration_saga DROP COLUMN IF EXISTS "category_ids";
+DROP TABLE IF EXISTS shop_category;
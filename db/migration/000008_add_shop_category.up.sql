@@ -0,0 +1,9 @@
+CREATE TABLE shop_category (
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "category_id" int8 NOT NULL,
+    PRIMARY KEY ("shop_id", "category_id")
+);
+
+CREATE INDEX ON shop_category ("category_id", "shop_id");
+
+ALTER TABLE shop_registration_saga ADD COLUMN "category_ids" int8[] NOT NULL DEFAULT '{}';
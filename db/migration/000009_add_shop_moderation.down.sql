@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS shop_moderation_log;
+ALTER TABLE shop DROP COLUMN IF EXISTS "status";
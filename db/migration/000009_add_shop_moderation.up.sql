@@ -0,0 +1,16 @@
+ALTER TABLE shop ADD COLUMN "status" varchar(16) NOT NULL DEFAULT 'active'
+    CHECK ("status" IN ('active', 'suspended', 'closed', 'pending_review'));
+
+CREATE INDEX ON shop ("status", "id");
+
+CREATE TABLE shop_moderation_log (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "admin_id" int8 NOT NULL,
+    "from_status" varchar(16) NOT NULL,
+    "to_status" varchar(16) NOT NULL,
+    "reason" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON shop_moderation_log ("shop_id", "id");
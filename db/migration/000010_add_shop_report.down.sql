@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shop_report;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE shop_report (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "reporter_id" int8 NOT NULL,
+    "reason" varchar(32) NOT NULL,
+    "detail" text NOT NULL DEFAULT '',
+    "resolved" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- one open report per reporter and shop
+CREATE UNIQUE INDEX shop_report_open_idx ON shop_report ("shop_id", "reporter_id") WHERE NOT "resolved";
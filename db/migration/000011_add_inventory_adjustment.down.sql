@@ -0,0 +1 @@
+DROP TABLE IF EXISTS inventory_adjustment;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE inventory_adjustment (
+    "id" serial8 PRIMARY KEY,
+    "product_id" int8 NOT NULL,
+    "actor_id" int8 NOT NULL,
+    "delta" int4 NOT NULL,
+    "reason" varchar(256) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON inventory_adjustment ("product_id", "id");
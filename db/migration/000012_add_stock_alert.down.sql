@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS stock_alert;
+DROP TABLE IF EXISTS stock_threshold;
\ No newline at end of file
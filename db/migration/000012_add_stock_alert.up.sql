@@ -0,0 +1,23 @@
+-- product_id 0 holds the default threshold of every product of the shop
+CREATE TABLE stock_threshold (
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "product_id" int8 NOT NULL,
+    "threshold" int4 NOT NULL CHECK ("threshold" >= 0),
+    PRIMARY KEY ("shop_id", "product_id")
+);
+
+CREATE TABLE stock_alert (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "product_id" int8 NOT NULL,
+    "product_name" varchar NOT NULL,
+    "inventory" int8 NOT NULL,
+    "threshold" int4 NOT NULL,
+    "resolved" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "resolved_at" timestamptz
+);
+
+CREATE INDEX ON stock_alert ("shop_id", "id");
+-- one open alert per product
+CREATE UNIQUE INDEX stock_alert_open_idx ON stock_alert ("product_id") WHERE NOT "resolved";
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS product_draft;
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE product_draft (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "seller_id" int8 NOT NULL,
+    "category_id" int8 NOT NULL DEFAULT 0,
+    "name" varchar NOT NULL DEFAULT '',
+    "description" text NOT NULL DEFAULT '',
+    "price" int8 NOT NULL DEFAULT 0,
+    "thumbnail" text NOT NULL DEFAULT '',
+    "inventory" int8 NOT NULL DEFAULT 0,
+    "brand" varchar NOT NULL DEFAULT '',
+    "status" varchar(16) NOT NULL DEFAULT 'draft'
+        CHECK ("status" IN ('draft', 'scheduled', 'publishing', 'published', 'publish_failed')),
+    "publish_at" timestamptz,
+    "attempts" int4 NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz,
+    "last_error" text NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON product_draft ("shop_id", "id");
+CREATE INDEX ON product_draft ("status", "next_attempt_at");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS shop_collection_item;
+DROP TABLE IF EXISTS shop_collection;
\ No newline at end of file
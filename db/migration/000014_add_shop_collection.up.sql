@@ -0,0 +1,19 @@
+CREATE TABLE shop_collection (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "name" varchar(64) NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    -- collections are shown by ascending position
+    "position" int4 NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON shop_collection ("shop_id", "position");
+
+CREATE TABLE shop_collection_item (
+    "collection_id" int8 NOT NULL REFERENCES shop_collection ("id") ON DELETE CASCADE,
+    "product_id" int8 NOT NULL,
+    "position" int4 NOT NULL,
+    PRIMARY KEY ("collection_id", "product_id")
+);
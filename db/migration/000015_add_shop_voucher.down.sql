@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS voucher_redemption;
+DROP TABLE IF EXISTS shop_voucher;
\ No newline at end of file
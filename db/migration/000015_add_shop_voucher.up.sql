@@ -0,0 +1,32 @@
+CREATE TABLE shop_voucher (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "code" varchar(32) NOT NULL,
+    "kind" varchar(16) NOT NULL CHECK ("kind" IN ('percentage', 'fixed_amount')),
+    -- percent off for percentage vouchers, amount off for fixed_amount ones
+    "value" int8 NOT NULL CHECK ("value" > 0),
+    "min_order_value" int8 NOT NULL DEFAULT 0,
+    -- 0 means unlimited
+    "usage_limit" int4 NOT NULL DEFAULT 0,
+    "per_user_limit" int4 NOT NULL DEFAULT 0,
+    "used_count" int4 NOT NULL DEFAULT 0,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    UNIQUE ("shop_id", "code"),
+    CHECK ("usage_limit" = 0 OR "used_count" <= "usage_limit")
+);
+
+CREATE TABLE voucher_redemption (
+    "id" serial8 PRIMARY KEY,
+    "voucher_id" int8 NOT NULL REFERENCES shop_voucher ("id") ON DELETE CASCADE,
+    "user_id" int8 NOT NULL,
+    "order_id" int8 NOT NULL,
+    "subtotal" int8 NOT NULL,
+    "discount" int8 NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    -- redeeming again for the same order is a retry
+    UNIQUE ("voucher_id", "order_id")
+);
+
+CREATE INDEX ON voucher_redemption ("voucher_id", "user_id");
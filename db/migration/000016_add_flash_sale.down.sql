@@ -0,0 +1 @@
+DROP TABLE IF EXISTS flash_sale;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE flash_sale (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "seller_id" int8 NOT NULL,
+    "product_id" int8 NOT NULL,
+    "sale_price" int8 NOT NULL CHECK ("sale_price" > 0),
+    -- units sold at the sale price
+    "quantity" int8 NOT NULL CHECK ("quantity" > 0),
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "status" varchar(16) NOT NULL DEFAULT 'sale_scheduled'
+        CHECK ("status" IN ('sale_scheduled', 'sale_starting', 'sale_running', 'sale_ending', 'sale_ended', 'sale_failed')),
+    -- what the sale changed on the product, so it can be undone
+    "original_price" int8 NOT NULL DEFAULT 0,
+    "price_applied" boolean NOT NULL DEFAULT false,
+    "withheld" int8 NOT NULL DEFAULT 0,
+    "last_error" text NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("ends_at" > "starts_at")
+);
+
+-- a product runs one sale at a time
+CREATE UNIQUE INDEX flash_sale_product_idx ON flash_sale ("product_id") WHERE "status" NOT IN ('sale_ended', 'sale_failed');
+CREATE INDEX ON flash_sale ("status", "starts_at");
+CREATE INDEX ON flash_sale ("status", "ends_at");
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS shop_opening_hours;
+
+ALTER TABLE shop
+    DROP COLUMN IF EXISTS "timezone",
+    DROP COLUMN IF EXISTS "vacation_start",
+    DROP COLUMN IF EXISTS "vacation_end",
+    DROP COLUMN IF EXISTS "vacation_message";
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE shop
+    ADD COLUMN "timezone" varchar(64) NOT NULL DEFAULT 'Asia/Ho_Chi_Minh',
+    ADD COLUMN "vacation_start" timestamptz,
+    ADD COLUMN "vacation_end" timestamptz,
+    ADD COLUMN "vacation_message" text NOT NULL DEFAULT '';
+
+-- weekly opening hours in the shop's timezone, a shop without any is always
+-- open
+CREATE TABLE shop_opening_hours (
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    -- 0 is Sunday
+    "weekday" int2 NOT NULL CHECK ("weekday" BETWEEN 0 AND 6),
+    -- minutes since midnight
+    "opens_at" int4 NOT NULL CHECK ("opens_at" BETWEEN 0 AND 1439),
+    "closes_at" int4 NOT NULL CHECK ("closes_at" BETWEEN 1 AND 1440),
+    PRIMARY KEY ("shop_id", "weekday", "opens_at"),
+    CHECK ("closes_at" > "opens_at")
+);
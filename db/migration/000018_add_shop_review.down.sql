@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS shop_review;
+
+ALTER TABLE shop
+    DROP COLUMN IF EXISTS "review_count",
+    DROP COLUMN IF EXISTS "review_rating_sum";
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE shop
+    ADD COLUMN "review_count" int8 NOT NULL DEFAULT 0,
+    ADD COLUMN "review_rating_sum" int8 NOT NULL DEFAULT 0;
+
+CREATE TABLE shop_review (
+    "id" serial8 PRIMARY KEY,
+    "shop_id" int8 NOT NULL REFERENCES shop ("id") ON DELETE CASCADE,
+    "user_id" int8 NOT NULL,
+    "rating" int2 NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+    "comment" text NOT NULL DEFAULT '',
+    "reply" text NOT NULL DEFAULT '',
+    "replied_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    -- one review per customer and shop
+    UNIQUE ("shop_id", "user_id")
+);
+
+CREATE INDEX ON shop_review ("shop_id", "id");
@@ -0,0 +1,8 @@
+ALTER TABLE shop_registration_saga
+    DROP CONSTRAINT shop_registration_saga_state_check,
+    ADD CONSTRAINT shop_registration_saga_state_check
+        CHECK ("state" IN ('pending', 'role_granted', 'shop_created', 'done', 'compensating', 'failed', 'abandoned'));
+
+-- an abandoned saga waits for an operator, it must not block a new registration
+DROP INDEX shop_registration_saga_seller_id_idx;
+CREATE UNIQUE INDEX ON shop_registration_saga ("seller_id") WHERE "state" NOT IN ('done', 'failed', 'abandoned');
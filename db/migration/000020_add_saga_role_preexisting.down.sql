@@ -0,0 +1 @@
+ALTER TABLE shop_registration_saga DROP COLUMN IF EXISTS "role_preexisting";
\ No newline at end of file
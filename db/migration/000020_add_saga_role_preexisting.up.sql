@@ -0,0 +1,3 @@
+-- set when the seller already held the supplier role, compensation must not
+-- revoke a role the saga did not grant
+ALTER TABLE shop_registration_saga ADD COLUMN "role_preexisting" boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE shop DROP COLUMN IF EXISTS "report_forward_pending_since";
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- set while the report of a flagged shop's seller still has to reach
+-- user-service, cleared once it did
+ALTER TABLE shop ADD COLUMN "report_forward_pending_since" timestamptz;
+
+CREATE INDEX ON shop ("report_forward_pending_since") WHERE "report_forward_pending_since" IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE flash_sale
+    DROP COLUMN IF EXISTS "withholding",
+    DROP COLUMN IF EXISTS "withhold_inventory",
+    DROP COLUMN IF EXISTS "withhold_sold";
\ No newline at end of file
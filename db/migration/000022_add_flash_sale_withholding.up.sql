@@ -0,0 +1,7 @@
+-- stock about to be withheld, recorded before DescInventory with the
+-- product's inventory and units sold at that time, so a crash or a lost
+-- response can be reconciled against the product
+ALTER TABLE flash_sale
+    ADD COLUMN "withholding" int8 NOT NULL DEFAULT 0,
+    ADD COLUMN "withhold_inventory" int8 NOT NULL DEFAULT 0,
+    ADD COLUMN "withhold_sold" int8 NOT NULL DEFAULT 0;
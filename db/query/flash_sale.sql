@@ -0,0 +1,66 @@
+-- name: CreateFlashSale :one
+INSERT INTO flash_sale ("shop_id", "seller_id", "product_id", "sale_price", "quantity", "starts_at", "ends_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetRunningFlashSaleByProduct :one
+SELECT * FROM flash_sale WHERE "product_id" = $1 AND "status" = 'sale_running';
+
+-- name: ListRunningFlashSales :many
+SELECT * FROM flash_sale
+WHERE "status" = 'sale_running'
+    AND (sqlc.arg(shop_id)::int8 = 0 OR "shop_id" = sqlc.arg(shop_id)::int8)
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ClaimFlashSalesToStart :many
+UPDATE flash_sale
+SET "status" = 'sale_starting', "updated_at" = now()
+WHERE "id" IN (
+    SELECT due."id" FROM flash_sale AS due
+    WHERE due."status" = 'sale_scheduled' AND due."starts_at" <= now() AND due."ends_at" > now()
+    ORDER BY due."starts_at"
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: FailMissedFlashSales :execrows
+UPDATE flash_sale
+SET "status" = 'sale_failed', "last_error" = sqlc.arg(last_error), "updated_at" = now()
+WHERE "status" = 'sale_scheduled' AND "ends_at" <= now();
+
+-- name: ClaimFlashSalesToEnd :many
+UPDATE flash_sale
+SET "status" = 'sale_ending', "updated_at" = now()
+WHERE "id" IN (
+    SELECT due."id" FROM flash_sale AS due
+    WHERE (due."status" = 'sale_running' AND due."ends_at" <= now())
+        -- interrupted by a crash
+        OR (due."status" IN ('sale_starting', 'sale_ending') AND due."updated_at" < sqlc.arg(stale_before))
+    ORDER BY due."ends_at"
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordFlashSalePrice :exec
+UPDATE flash_sale
+SET "price_applied" = $2, "original_price" = $3, "updated_at" = now()
+WHERE "id" = $1;
+
+-- name: RecordFlashSaleWithholding :exec
+UPDATE flash_sale
+SET "withholding" = $2, "withhold_inventory" = $3, "withhold_sold" = $4, "updated_at" = now()
+WHERE "id" = $1;
+
+-- name: RecordFlashSaleWithheld :exec
+UPDATE flash_sale
+SET "withheld" = $2, "withholding" = 0, "updated_at" = now()
+WHERE "id" = $1;
+
+-- name: FinishFlashSale :exec
+UPDATE flash_sale
+SET "status" = $2, "last_error" = $3, "updated_at" = now()
+WHERE "id" = $1;
@@ -0,0 +1,9 @@
+-- name: CreateInventoryAdjustment :exec
+INSERT INTO inventory_adjustment ("product_id", "actor_id", "delta", "reason") VALUES ($1, $2, $3, $4);
+
+-- name: ListInventoryAdjustments :many
+SELECT * FROM inventory_adjustment
+WHERE "product_id" = sqlc.arg(product_id)
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
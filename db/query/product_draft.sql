@@ -0,0 +1,72 @@
+-- name: CreateProductDraft :one
+INSERT INTO product_draft ("shop_id", "seller_id", "category_id", "name", "description", "price", "thumbnail", "inventory", "brand")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: UpdateProductDraft :one
+UPDATE product_draft
+SET "category_id" = $3, "name" = $4, "description" = $5, "price" = $6, "thumbnail" = $7, "inventory" = $8, "brand" = $9,
+    -- editing a failed draft starts it over
+    "status" = CASE WHEN "status" = 'publish_failed' THEN 'draft' ELSE "status" END,
+    "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING *;
+
+-- name: GetProductDraft :one
+SELECT * FROM product_draft WHERE "id" = $1 AND "seller_id" = $2;
+
+-- name: ListProductDrafts :many
+SELECT * FROM product_draft
+WHERE "seller_id" = sqlc.arg(seller_id)
+    AND (cardinality(sqlc.arg(statuses)::varchar[]) = 0 OR "status" = ANY(sqlc.arg(statuses)::varchar[]))
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ScheduleProductDraft :one
+UPDATE product_draft
+SET "status" = 'scheduled', "publish_at" = sqlc.arg(publish_at), "next_attempt_at" = sqlc.arg(publish_at),
+    "attempts" = 0, "last_error" = '', "updated_at" = now()
+WHERE "id" = sqlc.arg(id) AND "seller_id" = sqlc.arg(seller_id) AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING *;
+
+-- name: UnscheduleProductDraft :one
+UPDATE product_draft
+SET "status" = 'draft', "publish_at" = NULL, "next_attempt_at" = NULL, "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" = 'scheduled'
+RETURNING *;
+
+-- name: ClaimProductDraft :one
+UPDATE product_draft
+SET "status" = 'publishing', "attempts" = "attempts" + 1, "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING *;
+
+-- name: ClaimDueProductDrafts :many
+UPDATE product_draft
+SET "status" = 'publishing', "attempts" = "attempts" + 1, "updated_at" = now()
+WHERE "id" IN (
+    SELECT "id" FROM product_draft
+    WHERE "status" = 'scheduled' AND "next_attempt_at" <= now()
+    ORDER BY "next_attempt_at"
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: FinishProductDraft :one
+UPDATE product_draft
+SET "status" = sqlc.arg(status), "next_attempt_at" = sqlc.narg(next_attempt_at), "last_error" = sqlc.arg(last_error), "updated_at" = now()
+WHERE "id" = sqlc.arg(id) AND "status" = 'publishing'
+RETURNING *;
+
+-- name: PostponeProductDraft :one
+UPDATE product_draft
+SET "status" = 'scheduled', "next_attempt_at" = $2, "attempts" = "attempts" - 1, "updated_at" = now()
+WHERE "id" = $1 AND "status" = 'publishing'
+RETURNING *;
+
+-- name: FailStaleProductDrafts :execrows
+UPDATE product_draft
+SET "status" = 'publish_failed', "last_error" = sqlc.arg(last_error), "updated_at" = now()
+WHERE "status" = 'publishing' AND "updated_at" < sqlc.arg(stale_before);
@@ -0,0 +1,21 @@
+-- name: UpdateShopTimezone :exec
+UPDATE "shop"
+SET "timezone" = $2
+WHERE "id" = $1;
+
+-- name: UpdateShopVacation :one
+UPDATE "shop"
+SET "vacation_start" = $2, "vacation_end" = $3, "vacation_message" = $4
+WHERE "id" = $1
+RETURNING *;
+
+-- name: ListShopOpeningHours :many
+SELECT * FROM shop_opening_hours
+WHERE "shop_id" = $1
+ORDER BY "weekday", "opens_at";
+
+-- name: ClearShopOpeningHours :exec
+DELETE FROM shop_opening_hours WHERE "shop_id" = $1;
+
+-- name: AddShopOpeningHours :exec
+INSERT INTO shop_opening_hours ("shop_id", "weekday", "opens_at", "closes_at") VALUES ($1, $2, $3, $4);
@@ -0,0 +1,18 @@
+-- name: AddShopCategories :exec
+INSERT INTO shop_category ("shop_id", "category_id")
+SELECT sqlc.arg(shop_id), unnest(sqlc.arg(category_ids)::int8[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListShopCategoryIDs :many
+SELECT "category_id" FROM shop_category
+WHERE "shop_id" = $1
+ORDER BY "category_id";
+
+-- name: ListShopsByCategory :many
+SELECT shop.* FROM shop
+JOIN shop_category ON shop_category."shop_id" = shop."id"
+WHERE shop_category."category_id" = sqlc.arg(category_id)
+    AND shop."status" NOT IN ('suspended', 'closed')
+    AND (sqlc.arg(cursor)::int8 = 0 OR shop."id" < sqlc.arg(cursor)::int8)
+ORDER BY shop."id" DESC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,41 @@
+-- name: CreateShopCollection :one
+INSERT INTO shop_collection ("shop_id", "name", "description", "position") VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateShopCollection :one
+UPDATE shop_collection
+SET "name" = $3, "description" = $4, "position" = $5, "updated_at" = now()
+WHERE "id" = $1 AND "shop_id" = $2
+RETURNING *;
+
+-- name: DeleteShopCollection :execrows
+DELETE FROM shop_collection WHERE "id" = $1 AND "shop_id" = $2;
+
+-- name: GetShopCollection :one
+SELECT * FROM shop_collection WHERE "id" = $1;
+
+-- name: ListShopCollections :many
+SELECT shop_collection.*, (
+    SELECT count(*) FROM shop_collection_item WHERE shop_collection_item."collection_id" = shop_collection."id"
+)::int8 AS item_count
+FROM shop_collection
+WHERE "shop_id" = $1
+ORDER BY "position", "id";
+
+-- name: ListShopCollectionItems :many
+SELECT "product_id" FROM shop_collection_item
+WHERE "collection_id" = $1
+ORDER BY "position", "product_id";
+
+-- name: ClearShopCollectionItems :exec
+DELETE FROM shop_collection_item WHERE "collection_id" = $1;
+
+-- name: AddShopCollectionItem :exec
+INSERT INTO shop_collection_item ("collection_id", "product_id", "position") VALUES ($1, $2, $3);
+
+-- name: RemoveShopCollectionItems :exec
+DELETE FROM shop_collection_item
+WHERE "collection_id" = sqlc.arg(collection_id) AND "product_id" = ANY(sqlc.arg(product_ids)::int8[]);
+
+-- name: CountShopCollections :one
+SELECT count(*) FROM shop_collection WHERE "shop_id" = $1;
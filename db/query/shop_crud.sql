@@ -1,10 +1,50 @@
--- name: CreateShop :exec
-INSERT INTO shop ("seller_id", "name", "avatar") VALUES ($1, $2, $3);
+-- name: CreateShop :one
+INSERT INTO shop ("seller_id", "name", "avatar") VALUES ($1, $2, $3)
+RETURNING *;
 
 -- name: GetShopByID :one
+SELECT * FROM shop WHERE "id" = $1;
+
+-- name: GetShopBySellerID :one
 SELECT * FROM shop WHERE "seller_id" = $1;
 
 -- name: UpdateShopName :exec
 UPDATE "shop"
 SET "name" = $1
 WHERE "seller_id" = $2;
+
+-- name: GetOfficialShop :one
+SELECT * FROM shop WHERE "is_official";
+
+-- name: UpsertOfficialShop :exec
+INSERT INTO shop ("seller_id", "name", "is_official") VALUES ($1, $2, true)
+ON CONFLICT ("is_official") WHERE "is_official"
+DO UPDATE SET "seller_id" = EXCLUDED."seller_id", "name" = EXCLUDED."name";
+
+-- name: DemoteOfficialShop :exec
+UPDATE "shop"
+SET "is_official" = false
+WHERE "is_official" AND "seller_id" <> $1;
+
+-- name: PromoteOfficialShop :exec
+UPDATE "shop"
+SET "is_official" = true, "name" = $2
+WHERE "seller_id" = $1;
+
+-- name: UpdateShopAvatar :execrows
+UPDATE "shop"
+SET "avatar" = sqlc.arg(avatar)
+WHERE "seller_id" = sqlc.arg(seller_id)
+  AND "avatar" IS NOT DISTINCT FROM sqlc.narg(old_avatar);
+
+-- name: UpdateShopProfile :one
+UPDATE "shop"
+SET "name" = COALESCE(sqlc.narg(name), "name"),
+    "description" = COALESCE(sqlc.narg(description), "description"),
+    "avatar" = COALESCE(sqlc.narg(avatar), "avatar"),
+    "banner" = COALESCE(sqlc.narg(banner), "banner"),
+    "contact_phone" = COALESCE(sqlc.narg(contact_phone), "contact_phone"),
+    "contact_email" = COALESCE(sqlc.narg(contact_email), "contact_email"),
+    "address" = COALESCE(sqlc.narg(address), "address")
+WHERE "seller_id" = sqlc.arg(seller_id)
+RETURNING *;
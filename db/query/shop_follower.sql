@@ -0,0 +1,25 @@
+-- name: FollowShop :exec
+INSERT INTO shop_follower ("shop_id", "user_id") VALUES ($1, $2)
+ON CONFLICT ("shop_id", "user_id") DO NOTHING;
+
+-- name: UnfollowShop :exec
+DELETE FROM shop_follower WHERE "shop_id" = $1 AND "user_id" = $2;
+
+-- name: CountShopFollowers :one
+SELECT count(*) FROM shop_follower WHERE "shop_id" = $1;
+
+-- name: ListShopFollowers :many
+SELECT * FROM shop_follower
+WHERE "shop_id" = sqlc.arg(shop_id)
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListFollowedShops :many
+SELECT shop_follower."id" AS follow_id, shop_follower."created_at" AS followed_at, shop."id", shop."name", shop."avatar"
+FROM shop_follower
+JOIN shop ON shop."id" = shop_follower."shop_id"
+WHERE shop_follower."user_id" = sqlc.arg(user_id)
+    AND (sqlc.arg(cursor)::int8 = 0 OR shop_follower."id" < sqlc.arg(cursor)::int8)
+ORDER BY shop_follower."id" DESC
+LIMIT sqlc.arg(row_limit);
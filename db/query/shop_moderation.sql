@@ -0,0 +1,18 @@
+-- name: GetShopForUpdate :one
+SELECT * FROM shop WHERE "id" = $1 FOR UPDATE;
+
+-- name: UpdateShopStatus :exec
+UPDATE "shop"
+SET "status" = $2
+WHERE "id" = $1;
+
+-- name: CreateShopModerationLog :exec
+INSERT INTO shop_moderation_log ("shop_id", "admin_id", "from_status", "to_status", "reason")
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListShopsByStatus :many
+SELECT * FROM shop
+WHERE "status" = sqlc.arg(status)
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
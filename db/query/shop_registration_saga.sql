@@ -0,0 +1,28 @@
+-- name: CreateRegistrationSaga :one
+INSERT INTO shop_registration_saga ("seller_id", "name", "avatar", "category_ids", "role_preexisting") VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveRegistrationSaga :one
+SELECT * FROM shop_registration_saga
+WHERE "seller_id" = $1 AND "state" NOT IN ('done', 'failed', 'abandoned');
+
+-- name: TransitionRegistrationSaga :execrows
+UPDATE shop_registration_saga
+SET "state" = sqlc.arg(to_state), "updated_at" = now()
+WHERE "id" = sqlc.arg(id) AND "state" = sqlc.arg(from_state);
+
+-- name: RecordRegistrationSagaError :exec
+UPDATE shop_registration_saga
+SET "attempts" = "attempts" + 1, "last_error" = $2, "updated_at" = now()
+WHERE "id" = $1;
+
+-- name: ListStaleRegistrationSagas :many
+SELECT * FROM shop_registration_saga
+WHERE "state" NOT IN ('done', 'failed', 'abandoned') AND "updated_at" < sqlc.arg(stale_before)
+ORDER BY "id"
+LIMIT sqlc.arg(row_limit);
+
+-- name: MarkRegistrationSagaRolePreexisting :exec
+UPDATE shop_registration_saga
+SET "role_preexisting" = true, "updated_at" = now()
+WHERE "id" = $1;
@@ -0,0 +1,33 @@
+-- name: CreateShopReport :execrows
+INSERT INTO shop_report ("shop_id", "reporter_id", "reason", "detail") VALUES ($1, $2, $3, $4)
+ON CONFLICT ("shop_id", "reporter_id") WHERE NOT "resolved" DO NOTHING;
+
+-- name: CountOpenShopReporters :one
+SELECT count(DISTINCT "reporter_id") FROM shop_report
+WHERE "shop_id" = $1 AND NOT "resolved";
+
+-- name: ResolveShopReports :exec
+UPDATE shop_report
+SET "resolved" = true
+WHERE "shop_id" = $1 AND NOT "resolved";
+
+-- name: FlagShopForReview :execrows
+UPDATE "shop"
+SET "status" = 'pending_review'
+WHERE "id" = $1 AND "status" = 'active';
+
+-- name: MarkShopReportForwardPending :exec
+UPDATE "shop"
+SET "report_forward_pending_since" = now()
+WHERE "id" = $1;
+
+-- name: ClearShopReportForwardPending :exec
+UPDATE "shop"
+SET "report_forward_pending_since" = NULL
+WHERE "id" = $1;
+
+-- name: ListPendingShopReportForwards :many
+SELECT * FROM shop
+WHERE "report_forward_pending_since" < sqlc.arg(pending_before)
+ORDER BY "report_forward_pending_since"
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,30 @@
+-- name: GetShopReviewForUpdate :one
+SELECT * FROM shop_review WHERE "shop_id" = $1 AND "user_id" = $2 FOR UPDATE;
+
+-- name: CreateShopReview :one
+INSERT INTO shop_review ("shop_id", "user_id", "rating", "comment") VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateShopReview :one
+UPDATE shop_review
+SET "rating" = $2, "comment" = $3, "updated_at" = now()
+WHERE "id" = $1
+RETURNING *;
+
+-- name: AddShopRating :exec
+UPDATE "shop"
+SET "review_count" = "review_count" + sqlc.arg(count_delta), "review_rating_sum" = "review_rating_sum" + sqlc.arg(rating_delta)
+WHERE "id" = sqlc.arg(id);
+
+-- name: ListShopReviews :many
+SELECT * FROM shop_review
+WHERE "shop_id" = sqlc.arg(shop_id)
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ReplyShopReview :one
+UPDATE shop_review
+SET "reply" = $3, "replied_at" = now()
+WHERE "id" = $1 AND "shop_id" = $2
+RETURNING *;
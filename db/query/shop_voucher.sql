@@ -0,0 +1,35 @@
+-- name: CreateShopVoucher :one
+INSERT INTO shop_voucher ("shop_id", "code", "kind", "value", "min_order_value", "usage_limit", "per_user_limit", "starts_at", "ends_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetShopVoucherByCode :one
+SELECT * FROM shop_voucher WHERE "shop_id" = $1 AND "code" = $2;
+
+-- name: GetShopVoucherByCodeForUpdate :one
+SELECT * FROM shop_voucher WHERE "shop_id" = $1 AND "code" = $2 FOR UPDATE;
+
+-- name: ListActiveShopVouchers :many
+SELECT * FROM shop_voucher
+WHERE "shop_id" = sqlc.arg(shop_id)
+    AND "starts_at" <= now() AND "ends_at" > now()
+    AND ("usage_limit" = 0 OR "used_count" < "usage_limit")
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountUserVoucherRedemptions :one
+SELECT count(*) FROM voucher_redemption WHERE "voucher_id" = $1 AND "user_id" = $2;
+
+-- name: GetVoucherRedemptionByOrder :one
+SELECT * FROM voucher_redemption WHERE "voucher_id" = $1 AND "order_id" = $2;
+
+-- name: UseShopVoucher :execrows
+UPDATE shop_voucher
+SET "used_count" = "used_count" + 1
+WHERE "id" = $1 AND ("usage_limit" = 0 OR "used_count" < "usage_limit");
+
+-- name: CreateVoucherRedemption :one
+INSERT INTO voucher_redemption ("voucher_id", "user_id", "order_id", "subtotal", "discount")
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
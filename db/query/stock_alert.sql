@@ -0,0 +1,42 @@
+-- name: SetStockThreshold :one
+INSERT INTO stock_threshold ("shop_id", "product_id", "threshold") VALUES ($1, $2, $3)
+ON CONFLICT ("shop_id", "product_id") DO UPDATE SET "threshold" = EXCLUDED."threshold"
+RETURNING *;
+
+-- name: ListStockThresholds :many
+SELECT * FROM stock_threshold
+WHERE "shop_id" = $1
+ORDER BY "product_id";
+
+-- name: ListStockWatchedShops :many
+SELECT * FROM shop
+WHERE "status" NOT IN ('suspended', 'closed')
+    AND EXISTS (
+        SELECT 1 FROM stock_threshold
+        WHERE stock_threshold."shop_id" = shop."id" AND stock_threshold."threshold" > 0
+    )
+ORDER BY "id";
+
+-- name: CreateStockAlert :execrows
+INSERT INTO stock_alert ("shop_id", "product_id", "product_name", "inventory", "threshold")
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("product_id") WHERE NOT "resolved" DO NOTHING;
+
+-- name: ResolveStockAlert :exec
+UPDATE stock_alert
+SET "resolved" = true, "resolved_at" = now()
+WHERE "product_id" = $1 AND NOT "resolved";
+
+-- name: ListStockAlerts :many
+SELECT * FROM stock_alert
+WHERE "shop_id" = sqlc.arg(shop_id)
+    AND (sqlc.arg(include_resolved)::boolean OR NOT "resolved")
+    AND (sqlc.arg(cursor)::int8 = 0 OR "id" < sqlc.arg(cursor)::int8)
+ORDER BY "id" DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListOpenStockAlertsAfter :many
+SELECT * FROM stock_alert
+WHERE "shop_id" = sqlc.arg(shop_id) AND NOT "resolved" AND "id" > sqlc.arg(after_id)
+ORDER BY "id"
+LIMIT sqlc.arg(row_limit);
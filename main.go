@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	// timezones of shops, for images without a zoneinfo database
+	_ "time/tzdata"
 
+	"github.com/e-commerce-microservices/shop-service/auth"
 	"github.com/e-commerce-microservices/shop-service/pb"
 	"github.com/e-commerce-microservices/shop-service/repository"
 	"github.com/e-commerce-microservices/shop-service/service"
+	"github.com/e-commerce-microservices/shop-service/storage"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -19,9 +28,6 @@ import (
 )
 
 func main() {
-	// create grpc server
-	grpcServer := grpc.NewServer()
-
 	// init shop db connection
 	pgDSN := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
@@ -37,8 +43,27 @@ func main() {
 		log.Fatal("can't ping to user db", err)
 	}
 
-	// init shop queries
-	shopQueries := repository.New(shopDB)
+	// init shop store
+	shopStore := repository.NewStore(shopDB)
+
+	// make sure the platform-owned official shop exists
+	if officialSellerID := os.Getenv("OFFICIAL_SHOP_SELLER_ID"); officialSellerID != "" {
+		sellerID, err := strconv.ParseInt(officialSellerID, 10, 64)
+		if err != nil {
+			log.Fatal("invalid OFFICIAL_SHOP_SELLER_ID: ", err)
+		}
+		// an empty name keeps the name the official shop already has
+		officialName := strings.TrimSpace(os.Getenv("OFFICIAL_SHOP_NAME"))
+		if officialName != "" {
+			if err := service.ValidateShopName(officialName); err != nil {
+				log.Fatal("invalid OFFICIAL_SHOP_NAME: ", err)
+			}
+		}
+		err = shopStore.EnsureOfficialShop(context.Background(), sellerID, officialName)
+		if err != nil {
+			log.Fatal("can't create official shop: ", err)
+		}
+	}
 
 	// dial auth client
 	authServiceConn, err := grpc.Dial("auth-service:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -60,11 +85,45 @@ func main() {
 	productServiceConn, err := grpc.Dial("product-service:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	productClient := pb.NewProductServiceClient(productServiceConn)
 
+	// init avatar storage
+	avatarStore, err := storage.NewLocalStore(os.Getenv("BLOB_DIR"), os.Getenv("BLOB_BASE_URL"))
+	if err != nil {
+		log.Fatal("can't init blob store: ", err)
+	}
+	if addr := os.Getenv("BLOB_HTTP_ADDR"); addr != "" {
+		go func() {
+			log.Printf("serve blobs on %s", addr)
+			log.Println(http.ListenAndServe(addr, avatarStore.Handler()))
+		}()
+	}
+
+	// create grpc server, resolving the caller of every request once
+	authInterceptor := auth.NewInterceptor(authClient, userClient, service.Policies, os.Getenv("SHOP_SERVICE_TOKEN"))
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(authInterceptor.Unary()),
+		grpc.StreamInterceptor(authInterceptor.Stream()),
+	)
+
 	// create shop service
-	shopService := service.NewShopService(shopQueries, authClient, userClient, productClient)
+	shopService := service.NewShopService(shopStore, authClient, userClient, productClient, avatarStore, service.Config{
+		ReportThreshold: envInt("SHOP_REPORT_THRESHOLD", 5),
+		StatsCacheTTL:   time.Duration(envInt("SHOP_STATS_CACHE_TTL_SECONDS", 60)) * time.Second,
+		CatalogCacheTTL: time.Duration(envInt("SHOP_CATALOG_CACHE_TTL_SECONDS", 60)) * time.Second,
+	})
 	// register shop service
 	pb.RegisterShopServiceServer(grpcServer, shopService)
 
+	// resume shop registrations interrupted by a crash
+	go shopService.RecoverRegistrationSagas(context.Background(), time.Minute)
+	// publish scheduled product drafts
+	go shopService.PublishScheduledDrafts(context.Background(), 30*time.Second)
+	// start and end flash sales
+	go shopService.RunFlashSales(context.Background(), 15*time.Second)
+	// forward reports of flagged shops user-service missed
+	go shopService.ForwardShopReports(context.Background(), time.Minute)
+	// record low-stock alerts
+	go shopService.CheckStockLevels(context.Background(), time.Duration(envInt("STOCK_CHECK_INTERVAL_SECONDS", 300))*time.Second)
+
 	// listen and serve
 	listener, err := net.Listen("tcp", ":8080")
 	if err != nil {
@@ -78,6 +137,20 @@ func main() {
 	}
 }
 
+// envInt reads an integer setting, falling back to def when it is unset
+func envInt(key string, def int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return n
+}
+
 func init() {
 	err := godotenv.Load()
 	if err != nil {
@@ -30,6 +30,54 @@ type ShopServiceClient interface {
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
 	FollowShop(ctx context.Context, in *FollowShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	UnfollowShop(ctx context.Context, in *UnfollowShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	ListFollowers(ctx context.Context, in *ListFollowersRequest, opts ...grpc.CallOption) (*ListFollowersResponse, error)
+	ListFollowedShops(ctx context.Context, in *ListFollowedShopsRequest, opts ...grpc.CallOption) (*ListFollowedShopsResponse, error)
+	UpdateShopAvatar(ctx context.Context, opts ...grpc.CallOption) (ShopService_UpdateShopAvatarClient, error)
+	UpdateShopProfile(ctx context.Context, in *UpdateShopProfileRequest, opts ...grpc.CallOption) (*GetShopResponse, error)
+	ListShopsByCategory(ctx context.Context, in *ListShopsByCategoryRequest, opts ...grpc.CallOption) (*ListShopsByCategoryResponse, error)
+	SuspendShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	ReinstateShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	CloseShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	ListShopsForReview(ctx context.Context, in *ListShopsForReviewRequest, opts ...grpc.CallOption) (*ListShopsForReviewResponse, error)
+	ReportShop(ctx context.Context, in *ReportShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	ListMyProducts(ctx context.Context, in *ListMyProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	ListShopProducts(ctx context.Context, in *ListShopProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (ShopService_ImportProductsClient, error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ShopService_ExportProductsClient, error)
+	BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchUpdateProductsResponse, error)
+	GetInventory(ctx context.Context, in *ProductInventoryRequest, opts ...grpc.CallOption) (*ProductInventory, error)
+	RestockProduct(ctx context.Context, in *RestockProductRequest, opts ...grpc.CallOption) (*ProductInventory, error)
+	AdjustInventory(ctx context.Context, in *AdjustInventoryRequest, opts ...grpc.CallOption) (*ProductInventory, error)
+	ListInventoryAdjustments(ctx context.Context, in *ListInventoryAdjustmentsRequest, opts ...grpc.CallOption) (*ListInventoryAdjustmentsResponse, error)
+	SetStockThreshold(ctx context.Context, in *SetStockThresholdRequest, opts ...grpc.CallOption) (*StockThreshold, error)
+	ListStockThresholds(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListStockThresholdsResponse, error)
+	ListStockAlerts(ctx context.Context, in *ListStockAlertsRequest, opts ...grpc.CallOption) (*ListStockAlertsResponse, error)
+	WatchStockAlerts(ctx context.Context, in *WatchStockAlertsRequest, opts ...grpc.CallOption) (ShopService_WatchStockAlertsClient, error)
+	GetShopStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ShopStats, error)
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error)
+	ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*ListDraftsResponse, error)
+	PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error)
+	ScheduleDraft(ctx context.Context, in *ScheduleDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error)
+	UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error)
+	DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	SetCollectionItems(ctx context.Context, in *SetCollectionItemsRequest, opts ...grpc.CallOption) (*ShopCollection, error)
+	ListShopCollections(ctx context.Context, in *ListShopCollectionsRequest, opts ...grpc.CallOption) (*ListShopCollectionsResponse, error)
+	GetShopCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error)
+	CreateVoucher(ctx context.Context, in *CreateVoucherRequest, opts ...grpc.CallOption) (*Voucher, error)
+	ListShopVouchers(ctx context.Context, in *ListShopVouchersRequest, opts ...grpc.CallOption) (*ListShopVouchersResponse, error)
+	ValidateVoucher(ctx context.Context, in *ValidateVoucherRequest, opts ...grpc.CallOption) (*VoucherQuote, error)
+	RedeemVoucher(ctx context.Context, in *RedeemVoucherRequest, opts ...grpc.CallOption) (*VoucherQuote, error)
+	CreateFlashSale(ctx context.Context, in *CreateFlashSaleRequest, opts ...grpc.CallOption) (*FlashSale, error)
+	ListActiveFlashSales(ctx context.Context, in *ListActiveFlashSalesRequest, opts ...grpc.CallOption) (*ListActiveFlashSalesResponse, error)
+	GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePrice, error)
+	SetOpeningHours(ctx context.Context, in *SetOpeningHoursRequest, opts ...grpc.CallOption) (*ShopSchedule, error)
+	SetVacation(ctx context.Context, in *SetVacationRequest, opts ...grpc.CallOption) (*ShopSchedule, error)
+	GetShopAvailability(ctx context.Context, in *GetShopAvailabilityRequest, opts ...grpc.CallOption) (*ShopAvailability, error)
+	RateShop(ctx context.Context, in *RateShopRequest, opts ...grpc.CallOption) (*ShopReview, error)
+	ListShopReviews(ctx context.Context, in *ListShopReviewsRequest, opts ...grpc.CallOption) (*ListShopReviewsResponse, error)
+	ReplyShopReview(ctx context.Context, in *ReplyShopReviewRequest, opts ...grpc.CallOption) (*ShopReview, error)
 	UpdateShopName(ctx context.Context, in *UpdateShopNameRequest, opts ...grpc.CallOption) (*GetShopResponse, error)
 }
 
@@ -104,193 +152,1799 @@ func (c *shopServiceClient) FollowShop(ctx context.Context, in *FollowShopReques
 	return out, nil
 }
 
-func (c *shopServiceClient) UpdateShopName(ctx context.Context, in *UpdateShopNameRequest, opts ...grpc.CallOption) (*GetShopResponse, error) {
+func (c *shopServiceClient) UnfollowShop(ctx context.Context, in *UnfollowShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/UnfollowShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListFollowers(ctx context.Context, in *ListFollowersRequest, opts ...grpc.CallOption) (*ListFollowersResponse, error) {
+	out := new(ListFollowersResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListFollowers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListFollowedShops(ctx context.Context, in *ListFollowedShopsRequest, opts ...grpc.CallOption) (*ListFollowedShopsResponse, error) {
+	out := new(ListFollowedShopsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListFollowedShops", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) UpdateShopAvatar(ctx context.Context, opts ...grpc.CallOption) (ShopService_UpdateShopAvatarClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopService_ServiceDesc.Streams[0], "/ecommerce.ShopService/UpdateShopAvatar", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopServiceUpdateShopAvatarClient{stream}
+	return x, nil
+}
+
+type ShopService_UpdateShopAvatarClient interface {
+	Send(*UpdateShopAvatarRequest) error
+	CloseAndRecv() (*UpdateShopAvatarResponse, error)
+	grpc.ClientStream
+}
+
+type shopServiceUpdateShopAvatarClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopServiceUpdateShopAvatarClient) Send(m *UpdateShopAvatarRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shopServiceUpdateShopAvatarClient) CloseAndRecv() (*UpdateShopAvatarResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UpdateShopAvatarResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopServiceClient) UpdateShopProfile(ctx context.Context, in *UpdateShopProfileRequest, opts ...grpc.CallOption) (*GetShopResponse, error) {
 	out := new(GetShopResponse)
-	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/UpdateShopName", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/UpdateShopProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopsByCategory(ctx context.Context, in *ListShopsByCategoryRequest, opts ...grpc.CallOption) (*ListShopsByCategoryResponse, error) {
+	out := new(ListShopsByCategoryResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopsByCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SuspendShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SuspendShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ReinstateShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ReinstateShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) CloseShop(ctx context.Context, in *ModerateShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/CloseShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopsForReview(ctx context.Context, in *ListShopsForReviewRequest, opts ...grpc.CallOption) (*ListShopsForReviewResponse, error) {
+	out := new(ListShopsForReviewResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopsForReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ReportShop(ctx context.Context, in *ReportShopRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ReportShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListMyProducts(ctx context.Context, in *ListMyProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListMyProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopProducts(ctx context.Context, in *ListShopProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (ShopService_ImportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopService_ServiceDesc.Streams[1], "/ecommerce.ShopService/ImportProducts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopServiceImportProductsClient{stream}
+	return x, nil
+}
+
+type ShopService_ImportProductsClient interface {
+	Send(*ImportProductsRequest) error
+	CloseAndRecv() (*ImportProductsResponse, error)
+	grpc.ClientStream
+}
+
+type shopServiceImportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopServiceImportProductsClient) Send(m *ImportProductsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shopServiceImportProductsClient) CloseAndRecv() (*ImportProductsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportProductsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ShopService_ExportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopService_ServiceDesc.Streams[2], "/ecommerce.ShopService/ExportProducts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopServiceExportProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShopService_ExportProductsClient interface {
+	Recv() (*ExportProductsResponse, error)
+	grpc.ClientStream
+}
+
+type shopServiceExportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopServiceExportProductsClient) Recv() (*ExportProductsResponse, error) {
+	m := new(ExportProductsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopServiceClient) BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchUpdateProductsResponse, error) {
+	out := new(BatchUpdateProductsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/BatchUpdateProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) GetInventory(ctx context.Context, in *ProductInventoryRequest, opts ...grpc.CallOption) (*ProductInventory, error) {
+	out := new(ProductInventory)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/GetInventory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RestockProduct(ctx context.Context, in *RestockProductRequest, opts ...grpc.CallOption) (*ProductInventory, error) {
+	out := new(ProductInventory)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/RestockProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) AdjustInventory(ctx context.Context, in *AdjustInventoryRequest, opts ...grpc.CallOption) (*ProductInventory, error) {
+	out := new(ProductInventory)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/AdjustInventory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListInventoryAdjustments(ctx context.Context, in *ListInventoryAdjustmentsRequest, opts ...grpc.CallOption) (*ListInventoryAdjustmentsResponse, error) {
+	out := new(ListInventoryAdjustmentsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListInventoryAdjustments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SetStockThreshold(ctx context.Context, in *SetStockThresholdRequest, opts ...grpc.CallOption) (*StockThreshold, error) {
+	out := new(StockThreshold)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SetStockThreshold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListStockThresholds(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListStockThresholdsResponse, error) {
+	out := new(ListStockThresholdsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListStockThresholds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListStockAlerts(ctx context.Context, in *ListStockAlertsRequest, opts ...grpc.CallOption) (*ListStockAlertsResponse, error) {
+	out := new(ListStockAlertsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListStockAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) WatchStockAlerts(ctx context.Context, in *WatchStockAlertsRequest, opts ...grpc.CallOption) (ShopService_WatchStockAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopService_ServiceDesc.Streams[3], "/ecommerce.ShopService/WatchStockAlerts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopServiceWatchStockAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShopService_WatchStockAlertsClient interface {
+	Recv() (*StockAlert, error)
+	grpc.ClientStream
+}
+
+type shopServiceWatchStockAlertsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopServiceWatchStockAlertsClient) Recv() (*StockAlert, error) {
+	m := new(StockAlert)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopServiceClient) GetShopStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ShopStats, error) {
+	out := new(ShopStats)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/GetShopStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error) {
+	out := new(ProductDraft)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SaveDraft", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*ListDraftsResponse, error) {
+	out := new(ListDraftsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListDrafts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error) {
+	out := new(ProductDraft)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/PublishDraft", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ScheduleDraft(ctx context.Context, in *ScheduleDraftRequest, opts ...grpc.CallOption) (*ProductDraft, error) {
+	out := new(ProductDraft)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ScheduleDraft", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error) {
+	out := new(ShopCollection)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/CreateCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error) {
+	out := new(ShopCollection)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/UpdateCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/DeleteCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SetCollectionItems(ctx context.Context, in *SetCollectionItemsRequest, opts ...grpc.CallOption) (*ShopCollection, error) {
+	out := new(ShopCollection)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SetCollectionItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopCollections(ctx context.Context, in *ListShopCollectionsRequest, opts ...grpc.CallOption) (*ListShopCollectionsResponse, error) {
+	out := new(ListShopCollectionsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopCollections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) GetShopCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*ShopCollection, error) {
+	out := new(ShopCollection)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/GetShopCollection", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *shopServiceClient) CreateVoucher(ctx context.Context, in *CreateVoucherRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/CreateVoucher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopVouchers(ctx context.Context, in *ListShopVouchersRequest, opts ...grpc.CallOption) (*ListShopVouchersResponse, error) {
+	out := new(ListShopVouchersResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopVouchers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ValidateVoucher(ctx context.Context, in *ValidateVoucherRequest, opts ...grpc.CallOption) (*VoucherQuote, error) {
+	out := new(VoucherQuote)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ValidateVoucher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RedeemVoucher(ctx context.Context, in *RedeemVoucherRequest, opts ...grpc.CallOption) (*VoucherQuote, error) {
+	out := new(VoucherQuote)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/RedeemVoucher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) CreateFlashSale(ctx context.Context, in *CreateFlashSaleRequest, opts ...grpc.CallOption) (*FlashSale, error) {
+	out := new(FlashSale)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/CreateFlashSale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListActiveFlashSales(ctx context.Context, in *ListActiveFlashSalesRequest, opts ...grpc.CallOption) (*ListActiveFlashSalesResponse, error) {
+	out := new(ListActiveFlashSalesResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListActiveFlashSales", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePrice, error) {
+	out := new(EffectivePrice)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/GetEffectivePrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SetOpeningHours(ctx context.Context, in *SetOpeningHoursRequest, opts ...grpc.CallOption) (*ShopSchedule, error) {
+	out := new(ShopSchedule)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SetOpeningHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) SetVacation(ctx context.Context, in *SetVacationRequest, opts ...grpc.CallOption) (*ShopSchedule, error) {
+	out := new(ShopSchedule)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/SetVacation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) GetShopAvailability(ctx context.Context, in *GetShopAvailabilityRequest, opts ...grpc.CallOption) (*ShopAvailability, error) {
+	out := new(ShopAvailability)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/GetShopAvailability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RateShop(ctx context.Context, in *RateShopRequest, opts ...grpc.CallOption) (*ShopReview, error) {
+	out := new(ShopReview)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/RateShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopReviews(ctx context.Context, in *ListShopReviewsRequest, opts ...grpc.CallOption) (*ListShopReviewsResponse, error) {
+	out := new(ListShopReviewsResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ListShopReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ReplyShopReview(ctx context.Context, in *ReplyShopReviewRequest, opts ...grpc.CallOption) (*ShopReview, error) {
+	out := new(ShopReview)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/ReplyShopReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) UpdateShopName(ctx context.Context, in *UpdateShopNameRequest, opts ...grpc.CallOption) (*GetShopResponse, error) {
+	out := new(GetShopResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.ShopService/UpdateShopName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShopServiceServer is the server API for ShopService service.
+// All implementations must embed UnimplementedShopServiceServer
+// for forward compatibility
+type ShopServiceServer interface {
+	Ping(context.Context, *empty.Empty) (*Pong, error)
+	RegisterShop(context.Context, *RegisterShopRequest) (*GeneralResponse, error)
+	GetShop(context.Context, *GetShopRequest) (*GetShopResponse, error)
+	AddProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
+	UpdateProduct(context.Context, *UpdateProductRequest) (*GeneralResponse, error)
+	FollowShop(context.Context, *FollowShopRequest) (*GeneralResponse, error)
+	UnfollowShop(context.Context, *UnfollowShopRequest) (*GeneralResponse, error)
+	ListFollowers(context.Context, *ListFollowersRequest) (*ListFollowersResponse, error)
+	ListFollowedShops(context.Context, *ListFollowedShopsRequest) (*ListFollowedShopsResponse, error)
+	UpdateShopAvatar(ShopService_UpdateShopAvatarServer) error
+	UpdateShopProfile(context.Context, *UpdateShopProfileRequest) (*GetShopResponse, error)
+	ListShopsByCategory(context.Context, *ListShopsByCategoryRequest) (*ListShopsByCategoryResponse, error)
+	SuspendShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error)
+	ReinstateShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error)
+	CloseShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error)
+	ListShopsForReview(context.Context, *ListShopsForReviewRequest) (*ListShopsForReviewResponse, error)
+	ReportShop(context.Context, *ReportShopRequest) (*GeneralResponse, error)
+	ListMyProducts(context.Context, *ListMyProductsRequest) (*ListProductsResponse, error)
+	ListShopProducts(context.Context, *ListShopProductsRequest) (*ListProductsResponse, error)
+	ImportProducts(ShopService_ImportProductsServer) error
+	ExportProducts(*ExportProductsRequest, ShopService_ExportProductsServer) error
+	BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchUpdateProductsResponse, error)
+	GetInventory(context.Context, *ProductInventoryRequest) (*ProductInventory, error)
+	RestockProduct(context.Context, *RestockProductRequest) (*ProductInventory, error)
+	AdjustInventory(context.Context, *AdjustInventoryRequest) (*ProductInventory, error)
+	ListInventoryAdjustments(context.Context, *ListInventoryAdjustmentsRequest) (*ListInventoryAdjustmentsResponse, error)
+	SetStockThreshold(context.Context, *SetStockThresholdRequest) (*StockThreshold, error)
+	ListStockThresholds(context.Context, *empty.Empty) (*ListStockThresholdsResponse, error)
+	ListStockAlerts(context.Context, *ListStockAlertsRequest) (*ListStockAlertsResponse, error)
+	WatchStockAlerts(*WatchStockAlertsRequest, ShopService_WatchStockAlertsServer) error
+	GetShopStats(context.Context, *empty.Empty) (*ShopStats, error)
+	SaveDraft(context.Context, *SaveDraftRequest) (*ProductDraft, error)
+	ListDrafts(context.Context, *ListDraftsRequest) (*ListDraftsResponse, error)
+	PublishDraft(context.Context, *PublishDraftRequest) (*ProductDraft, error)
+	ScheduleDraft(context.Context, *ScheduleDraftRequest) (*ProductDraft, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*ShopCollection, error)
+	UpdateCollection(context.Context, *UpdateCollectionRequest) (*ShopCollection, error)
+	DeleteCollection(context.Context, *CollectionRequest) (*GeneralResponse, error)
+	SetCollectionItems(context.Context, *SetCollectionItemsRequest) (*ShopCollection, error)
+	ListShopCollections(context.Context, *ListShopCollectionsRequest) (*ListShopCollectionsResponse, error)
+	GetShopCollection(context.Context, *CollectionRequest) (*ShopCollection, error)
+	CreateVoucher(context.Context, *CreateVoucherRequest) (*Voucher, error)
+	ListShopVouchers(context.Context, *ListShopVouchersRequest) (*ListShopVouchersResponse, error)
+	ValidateVoucher(context.Context, *ValidateVoucherRequest) (*VoucherQuote, error)
+	RedeemVoucher(context.Context, *RedeemVoucherRequest) (*VoucherQuote, error)
+	CreateFlashSale(context.Context, *CreateFlashSaleRequest) (*FlashSale, error)
+	ListActiveFlashSales(context.Context, *ListActiveFlashSalesRequest) (*ListActiveFlashSalesResponse, error)
+	GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePrice, error)
+	SetOpeningHours(context.Context, *SetOpeningHoursRequest) (*ShopSchedule, error)
+	SetVacation(context.Context, *SetVacationRequest) (*ShopSchedule, error)
+	GetShopAvailability(context.Context, *GetShopAvailabilityRequest) (*ShopAvailability, error)
+	RateShop(context.Context, *RateShopRequest) (*ShopReview, error)
+	ListShopReviews(context.Context, *ListShopReviewsRequest) (*ListShopReviewsResponse, error)
+	ReplyShopReview(context.Context, *ReplyShopReviewRequest) (*ShopReview, error)
+	UpdateShopName(context.Context, *UpdateShopNameRequest) (*GetShopResponse, error)
+	mustEmbedUnimplementedShopServiceServer()
+}
+
+// UnimplementedShopServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedShopServiceServer struct {
+}
+
+func (UnimplementedShopServiceServer) Ping(context.Context, *empty.Empty) (*Pong, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedShopServiceServer) RegisterShop(context.Context, *RegisterShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterShop not implemented")
+}
+func (UnimplementedShopServiceServer) GetShop(context.Context, *GetShopRequest) (*GetShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShop not implemented")
+}
+func (UnimplementedShopServiceServer) AddProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+}
+func (UnimplementedShopServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedShopServiceServer) FollowShop(context.Context, *FollowShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowShop not implemented")
+}
+func (UnimplementedShopServiceServer) UnfollowShop(context.Context, *UnfollowShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowShop not implemented")
+}
+func (UnimplementedShopServiceServer) ListFollowers(context.Context, *ListFollowersRequest) (*ListFollowersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowers not implemented")
+}
+func (UnimplementedShopServiceServer) ListFollowedShops(context.Context, *ListFollowedShopsRequest) (*ListFollowedShopsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowedShops not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateShopAvatar(ShopService_UpdateShopAvatarServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateShopAvatar not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateShopProfile(context.Context, *UpdateShopProfileRequest) (*GetShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShopProfile not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopsByCategory(context.Context, *ListShopsByCategoryRequest) (*ListShopsByCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopsByCategory not implemented")
+}
+func (UnimplementedShopServiceServer) SuspendShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendShop not implemented")
+}
+func (UnimplementedShopServiceServer) ReinstateShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReinstateShop not implemented")
+}
+func (UnimplementedShopServiceServer) CloseShop(context.Context, *ModerateShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseShop not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopsForReview(context.Context, *ListShopsForReviewRequest) (*ListShopsForReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopsForReview not implemented")
+}
+func (UnimplementedShopServiceServer) ReportShop(context.Context, *ReportShopRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportShop not implemented")
+}
+func (UnimplementedShopServiceServer) ListMyProducts(context.Context, *ListMyProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyProducts not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopProducts(context.Context, *ListShopProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopProducts not implemented")
+}
+func (UnimplementedShopServiceServer) ImportProducts(ShopService_ImportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedShopServiceServer) ExportProducts(*ExportProductsRequest, ShopService_ExportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedShopServiceServer) BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchUpdateProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateProducts not implemented")
+}
+func (UnimplementedShopServiceServer) GetInventory(context.Context, *ProductInventoryRequest) (*ProductInventory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
+}
+func (UnimplementedShopServiceServer) RestockProduct(context.Context, *RestockProductRequest) (*ProductInventory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockProduct not implemented")
+}
+func (UnimplementedShopServiceServer) AdjustInventory(context.Context, *AdjustInventoryRequest) (*ProductInventory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustInventory not implemented")
+}
+func (UnimplementedShopServiceServer) ListInventoryAdjustments(context.Context, *ListInventoryAdjustmentsRequest) (*ListInventoryAdjustmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInventoryAdjustments not implemented")
+}
+func (UnimplementedShopServiceServer) SetStockThreshold(context.Context, *SetStockThresholdRequest) (*StockThreshold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStockThreshold not implemented")
+}
+func (UnimplementedShopServiceServer) ListStockThresholds(context.Context, *empty.Empty) (*ListStockThresholdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockThresholds not implemented")
+}
+func (UnimplementedShopServiceServer) ListStockAlerts(context.Context, *ListStockAlertsRequest) (*ListStockAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockAlerts not implemented")
+}
+func (UnimplementedShopServiceServer) WatchStockAlerts(*WatchStockAlertsRequest, ShopService_WatchStockAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStockAlerts not implemented")
+}
+func (UnimplementedShopServiceServer) GetShopStats(context.Context, *empty.Empty) (*ShopStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShopStats not implemented")
+}
+func (UnimplementedShopServiceServer) SaveDraft(context.Context, *SaveDraftRequest) (*ProductDraft, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedShopServiceServer) ListDrafts(context.Context, *ListDraftsRequest) (*ListDraftsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDrafts not implemented")
+}
+func (UnimplementedShopServiceServer) PublishDraft(context.Context, *PublishDraftRequest) (*ProductDraft, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishDraft not implemented")
+}
+func (UnimplementedShopServiceServer) ScheduleDraft(context.Context, *ScheduleDraftRequest) (*ProductDraft, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleDraft not implemented")
+}
+func (UnimplementedShopServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*ShopCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*ShopCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
+}
+func (UnimplementedShopServiceServer) DeleteCollection(context.Context, *CollectionRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedShopServiceServer) SetCollectionItems(context.Context, *SetCollectionItemsRequest) (*ShopCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCollectionItems not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopCollections(context.Context, *ListShopCollectionsRequest) (*ListShopCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopCollections not implemented")
+}
+func (UnimplementedShopServiceServer) GetShopCollection(context.Context, *CollectionRequest) (*ShopCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShopCollection not implemented")
+}
+func (UnimplementedShopServiceServer) CreateVoucher(context.Context, *CreateVoucherRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVoucher not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopVouchers(context.Context, *ListShopVouchersRequest) (*ListShopVouchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopVouchers not implemented")
+}
+func (UnimplementedShopServiceServer) ValidateVoucher(context.Context, *ValidateVoucherRequest) (*VoucherQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateVoucher not implemented")
+}
+func (UnimplementedShopServiceServer) RedeemVoucher(context.Context, *RedeemVoucherRequest) (*VoucherQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemVoucher not implemented")
+}
+func (UnimplementedShopServiceServer) CreateFlashSale(context.Context, *CreateFlashSaleRequest) (*FlashSale, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFlashSale not implemented")
+}
+func (UnimplementedShopServiceServer) ListActiveFlashSales(context.Context, *ListActiveFlashSalesRequest) (*ListActiveFlashSalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveFlashSales not implemented")
+}
+func (UnimplementedShopServiceServer) GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePrice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectivePrice not implemented")
+}
+func (UnimplementedShopServiceServer) SetOpeningHours(context.Context, *SetOpeningHoursRequest) (*ShopSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOpeningHours not implemented")
+}
+func (UnimplementedShopServiceServer) SetVacation(context.Context, *SetVacationRequest) (*ShopSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVacation not implemented")
+}
+func (UnimplementedShopServiceServer) GetShopAvailability(context.Context, *GetShopAvailabilityRequest) (*ShopAvailability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShopAvailability not implemented")
+}
+func (UnimplementedShopServiceServer) RateShop(context.Context, *RateShopRequest) (*ShopReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateShop not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopReviews(context.Context, *ListShopReviewsRequest) (*ListShopReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopReviews not implemented")
+}
+func (UnimplementedShopServiceServer) ReplyShopReview(context.Context, *ReplyShopReviewRequest) (*ShopReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplyShopReview not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateShopName(context.Context, *UpdateShopNameRequest) (*GetShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShopName not implemented")
+}
+func (UnimplementedShopServiceServer) mustEmbedUnimplementedShopServiceServer() {}
+
+// UnsafeShopServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShopServiceServer will
+// result in compilation errors.
+type UnsafeShopServiceServer interface {
+	mustEmbedUnimplementedShopServiceServer()
+}
+
+func RegisterShopServiceServer(s grpc.ServiceRegistrar, srv ShopServiceServer) {
+	s.RegisterService(&ShopService_ServiceDesc, srv)
+}
+
+func _ShopService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).Ping(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_RegisterShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RegisterShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/RegisterShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RegisterShop(ctx, req.(*RegisterShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_GetShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).GetShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/GetShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).GetShop(ctx, req.(*GetShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).AddProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/AddProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).AddProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/DeleteProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/UpdateProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_FollowShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).FollowShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/FollowShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).FollowShop(ctx, req.(*FollowShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_UnfollowShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).UnfollowShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/UnfollowShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).UnfollowShop(ctx, req.(*UnfollowShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListFollowers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListFollowers(ctx, req.(*ListFollowersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListFollowedShops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowedShopsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListFollowedShops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListFollowedShops",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListFollowedShops(ctx, req.(*ListFollowedShopsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_UpdateShopAvatar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopServiceServer).UpdateShopAvatar(&shopServiceUpdateShopAvatarServer{stream})
+}
+
+type ShopService_UpdateShopAvatarServer interface {
+	SendAndClose(*UpdateShopAvatarResponse) error
+	Recv() (*UpdateShopAvatarRequest, error)
+	grpc.ServerStream
+}
+
+type shopServiceUpdateShopAvatarServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopServiceUpdateShopAvatarServer) SendAndClose(m *UpdateShopAvatarResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shopServiceUpdateShopAvatarServer) Recv() (*UpdateShopAvatarRequest, error) {
+	m := new(UpdateShopAvatarRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ShopService_UpdateShopProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShopProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).UpdateShopProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/UpdateShopProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).UpdateShopProfile(ctx, req.(*UpdateShopProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListShopsByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopsByCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopsByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListShopsByCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopsByCategory(ctx, req.(*ListShopsByCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_SuspendShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).SuspendShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/SuspendShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).SuspendShop(ctx, req.(*ModerateShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ReinstateShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ReinstateShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ReinstateShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ReinstateShop(ctx, req.(*ModerateShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_CloseShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).CloseShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/CloseShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).CloseShop(ctx, req.(*ModerateShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListShopsForReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopsForReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopsForReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListShopsForReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopsForReview(ctx, req.(*ListShopsForReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ReportShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ReportShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ReportShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ReportShop(ctx, req.(*ReportShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListMyProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListMyProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListMyProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListMyProducts(ctx, req.(*ListMyProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListShopProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListShopProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopProducts(ctx, req.(*ListShopProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopServiceServer).ImportProducts(&shopServiceImportProductsServer{stream})
+}
+
+type ShopService_ImportProductsServer interface {
+	SendAndClose(*ImportProductsResponse) error
+	Recv() (*ImportProductsRequest, error)
+	grpc.ServerStream
+}
+
+type shopServiceImportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopServiceImportProductsServer) SendAndClose(m *ImportProductsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shopServiceImportProductsServer) Recv() (*ImportProductsRequest, error) {
+	m := new(ImportProductsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ShopService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShopServiceServer).ExportProducts(m, &shopServiceExportProductsServer{stream})
+}
+
+type ShopService_ExportProductsServer interface {
+	Send(*ExportProductsResponse) error
+	grpc.ServerStream
+}
+
+type shopServiceExportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopServiceExportProductsServer) Send(m *ExportProductsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ShopService_BatchUpdateProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).BatchUpdateProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/BatchUpdateProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).BatchUpdateProducts(ctx, req.(*BatchUpdateProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).GetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/GetInventory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).GetInventory(ctx, req.(*ProductInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_RestockProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RestockProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/RestockProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RestockProduct(ctx, req.(*RestockProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_AdjustInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).AdjustInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/AdjustInventory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).AdjustInventory(ctx, req.(*AdjustInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListInventoryAdjustments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoryAdjustmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListInventoryAdjustments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListInventoryAdjustments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListInventoryAdjustments(ctx, req.(*ListInventoryAdjustmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_SetStockThreshold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockThresholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).SetStockThreshold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/SetStockThreshold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).SetStockThreshold(ctx, req.(*SetStockThresholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListStockThresholds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListStockThresholds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListStockThresholds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListStockThresholds(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListStockAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListStockAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListStockAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListStockAlerts(ctx, req.(*ListStockAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_WatchStockAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStockAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShopServiceServer).WatchStockAlerts(m, &shopServiceWatchStockAlertsServer{stream})
+}
+
+type ShopService_WatchStockAlertsServer interface {
+	Send(*StockAlert) error
+	grpc.ServerStream
+}
+
+type shopServiceWatchStockAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopServiceWatchStockAlertsServer) Send(m *StockAlert) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ShopService_GetShopStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).GetShopStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/GetShopStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).GetShopStats(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/SaveDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).SaveDraft(ctx, req.(*SaveDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListDrafts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListDrafts(ctx, req.(*ListDraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_PublishDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).PublishDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/PublishDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).PublishDraft(ctx, req.(*PublishDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ScheduleDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ScheduleDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ScheduleDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ScheduleDraft(ctx, req.(*ScheduleDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/CreateCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).UpdateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/UpdateCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).UpdateCollection(ctx, req.(*UpdateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/DeleteCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).DeleteCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_SetCollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).SetCollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/SetCollectionItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).SetCollectionItems(ctx, req.(*SetCollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListShopCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListShopCollections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopCollections(ctx, req.(*ListShopCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_GetShopCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).GetShopCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/GetShopCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).GetShopCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// ShopServiceServer is the server API for ShopService service.
-// All implementations must embed UnimplementedShopServiceServer
-// for forward compatibility
-type ShopServiceServer interface {
-	Ping(context.Context, *empty.Empty) (*Pong, error)
-	RegisterShop(context.Context, *RegisterShopRequest) (*GeneralResponse, error)
-	GetShop(context.Context, *GetShopRequest) (*GetShopResponse, error)
-	AddProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
-	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
-	UpdateProduct(context.Context, *UpdateProductRequest) (*GeneralResponse, error)
-	FollowShop(context.Context, *FollowShopRequest) (*GeneralResponse, error)
-	UpdateShopName(context.Context, *UpdateShopNameRequest) (*GetShopResponse, error)
-	mustEmbedUnimplementedShopServiceServer()
+func _ShopService_CreateVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).CreateVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/CreateVoucher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).CreateVoucher(ctx, req.(*CreateVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedShopServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedShopServiceServer struct {
+func _ShopService_ListShopVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopVouchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListShopVouchers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopVouchers(ctx, req.(*ListShopVouchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedShopServiceServer) Ping(context.Context, *empty.Empty) (*Pong, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
-}
-func (UnimplementedShopServiceServer) RegisterShop(context.Context, *RegisterShopRequest) (*GeneralResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterShop not implemented")
-}
-func (UnimplementedShopServiceServer) GetShop(context.Context, *GetShopRequest) (*GetShopResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetShop not implemented")
-}
-func (UnimplementedShopServiceServer) AddProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
-}
-func (UnimplementedShopServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
-}
-func (UnimplementedShopServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*GeneralResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
-}
-func (UnimplementedShopServiceServer) FollowShop(context.Context, *FollowShopRequest) (*GeneralResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FollowShop not implemented")
+func _ShopService_ValidateVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ValidateVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ValidateVoucher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ValidateVoucher(ctx, req.(*ValidateVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShopServiceServer) UpdateShopName(context.Context, *UpdateShopNameRequest) (*GetShopResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateShopName not implemented")
+
+func _ShopService_RedeemVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RedeemVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/RedeemVoucher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RedeemVoucher(ctx, req.(*RedeemVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShopServiceServer) mustEmbedUnimplementedShopServiceServer() {}
 
-// UnsafeShopServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ShopServiceServer will
-// result in compilation errors.
-type UnsafeShopServiceServer interface {
-	mustEmbedUnimplementedShopServiceServer()
+func _ShopService_CreateFlashSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFlashSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).CreateFlashSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/CreateFlashSale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).CreateFlashSale(ctx, req.(*CreateFlashSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterShopServiceServer(s grpc.ServiceRegistrar, srv ShopServiceServer) {
-	s.RegisterService(&ShopService_ServiceDesc, srv)
+func _ShopService_ListActiveFlashSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveFlashSalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListActiveFlashSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.ShopService/ListActiveFlashSales",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListActiveFlashSales(ctx, req.(*ListActiveFlashSalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _ShopService_GetEffectivePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectivePriceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).Ping(ctx, in)
+		return srv.(ShopServiceServer).GetEffectivePrice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/Ping",
+		FullMethod: "/ecommerce.ShopService/GetEffectivePrice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).Ping(ctx, req.(*empty.Empty))
+		return srv.(ShopServiceServer).GetEffectivePrice(ctx, req.(*GetEffectivePriceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_RegisterShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterShopRequest)
+func _ShopService_SetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOpeningHoursRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).RegisterShop(ctx, in)
+		return srv.(ShopServiceServer).SetOpeningHours(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/RegisterShop",
+		FullMethod: "/ecommerce.ShopService/SetOpeningHours",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).RegisterShop(ctx, req.(*RegisterShopRequest))
+		return srv.(ShopServiceServer).SetOpeningHours(ctx, req.(*SetOpeningHoursRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_GetShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetShopRequest)
+func _ShopService_SetVacation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVacationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).GetShop(ctx, in)
+		return srv.(ShopServiceServer).SetVacation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/GetShop",
+		FullMethod: "/ecommerce.ShopService/SetVacation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).GetShop(ctx, req.(*GetShopRequest))
+		return srv.(ShopServiceServer).SetVacation(ctx, req.(*SetVacationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProductRequest)
+func _ShopService_GetShopAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShopAvailabilityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).AddProduct(ctx, in)
+		return srv.(ShopServiceServer).GetShopAvailability(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/AddProduct",
+		FullMethod: "/ecommerce.ShopService/GetShopAvailability",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).AddProduct(ctx, req.(*CreateProductRequest))
+		return srv.(ShopServiceServer).GetShopAvailability(ctx, req.(*GetShopAvailabilityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteProductRequest)
+func _ShopService_RateShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateShopRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).DeleteProduct(ctx, in)
+		return srv.(ShopServiceServer).RateShop(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/DeleteProduct",
+		FullMethod: "/ecommerce.ShopService/RateShop",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+		return srv.(ShopServiceServer).RateShop(ctx, req.(*RateShopRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateProductRequest)
+func _ShopService_ListShopReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopReviewsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).UpdateProduct(ctx, in)
+		return srv.(ShopServiceServer).ListShopReviews(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/UpdateProduct",
+		FullMethod: "/ecommerce.ShopService/ListShopReviews",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+		return srv.(ShopServiceServer).ListShopReviews(ctx, req.(*ListShopReviewsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopService_FollowShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FollowShopRequest)
+func _ShopService_ReplyShopReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplyShopReviewRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopServiceServer).FollowShop(ctx, in)
+		return srv.(ShopServiceServer).ReplyShopReview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ecommerce.ShopService/FollowShop",
+		FullMethod: "/ecommerce.ShopService/ReplyShopReview",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopServiceServer).FollowShop(ctx, req.(*FollowShopRequest))
+		return srv.(ShopServiceServer).ReplyShopReview(ctx, req.(*ReplyShopReviewRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -348,11 +2002,208 @@ var ShopService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FollowShop",
 			Handler:    _ShopService_FollowShop_Handler,
 		},
+		{
+			MethodName: "UnfollowShop",
+			Handler:    _ShopService_UnfollowShop_Handler,
+		},
+		{
+			MethodName: "ListFollowers",
+			Handler:    _ShopService_ListFollowers_Handler,
+		},
+		{
+			MethodName: "ListFollowedShops",
+			Handler:    _ShopService_ListFollowedShops_Handler,
+		},
+		{
+			MethodName: "UpdateShopProfile",
+			Handler:    _ShopService_UpdateShopProfile_Handler,
+		},
+		{
+			MethodName: "ListShopsByCategory",
+			Handler:    _ShopService_ListShopsByCategory_Handler,
+		},
+		{
+			MethodName: "SuspendShop",
+			Handler:    _ShopService_SuspendShop_Handler,
+		},
+		{
+			MethodName: "ReinstateShop",
+			Handler:    _ShopService_ReinstateShop_Handler,
+		},
+		{
+			MethodName: "CloseShop",
+			Handler:    _ShopService_CloseShop_Handler,
+		},
+		{
+			MethodName: "ListShopsForReview",
+			Handler:    _ShopService_ListShopsForReview_Handler,
+		},
+		{
+			MethodName: "ReportShop",
+			Handler:    _ShopService_ReportShop_Handler,
+		},
+		{
+			MethodName: "ListMyProducts",
+			Handler:    _ShopService_ListMyProducts_Handler,
+		},
+		{
+			MethodName: "ListShopProducts",
+			Handler:    _ShopService_ListShopProducts_Handler,
+		},
+		{
+			MethodName: "BatchUpdateProducts",
+			Handler:    _ShopService_BatchUpdateProducts_Handler,
+		},
+		{
+			MethodName: "GetInventory",
+			Handler:    _ShopService_GetInventory_Handler,
+		},
+		{
+			MethodName: "RestockProduct",
+			Handler:    _ShopService_RestockProduct_Handler,
+		},
+		{
+			MethodName: "AdjustInventory",
+			Handler:    _ShopService_AdjustInventory_Handler,
+		},
+		{
+			MethodName: "ListInventoryAdjustments",
+			Handler:    _ShopService_ListInventoryAdjustments_Handler,
+		},
+		{
+			MethodName: "SetStockThreshold",
+			Handler:    _ShopService_SetStockThreshold_Handler,
+		},
+		{
+			MethodName: "ListStockThresholds",
+			Handler:    _ShopService_ListStockThresholds_Handler,
+		},
+		{
+			MethodName: "ListStockAlerts",
+			Handler:    _ShopService_ListStockAlerts_Handler,
+		},
+		{
+			MethodName: "GetShopStats",
+			Handler:    _ShopService_GetShopStats_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _ShopService_SaveDraft_Handler,
+		},
+		{
+			MethodName: "ListDrafts",
+			Handler:    _ShopService_ListDrafts_Handler,
+		},
+		{
+			MethodName: "PublishDraft",
+			Handler:    _ShopService_PublishDraft_Handler,
+		},
+		{
+			MethodName: "ScheduleDraft",
+			Handler:    _ShopService_ScheduleDraft_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _ShopService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "UpdateCollection",
+			Handler:    _ShopService_UpdateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _ShopService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "SetCollectionItems",
+			Handler:    _ShopService_SetCollectionItems_Handler,
+		},
+		{
+			MethodName: "ListShopCollections",
+			Handler:    _ShopService_ListShopCollections_Handler,
+		},
+		{
+			MethodName: "GetShopCollection",
+			Handler:    _ShopService_GetShopCollection_Handler,
+		},
+		{
+			MethodName: "CreateVoucher",
+			Handler:    _ShopService_CreateVoucher_Handler,
+		},
+		{
+			MethodName: "ListShopVouchers",
+			Handler:    _ShopService_ListShopVouchers_Handler,
+		},
+		{
+			MethodName: "ValidateVoucher",
+			Handler:    _ShopService_ValidateVoucher_Handler,
+		},
+		{
+			MethodName: "RedeemVoucher",
+			Handler:    _ShopService_RedeemVoucher_Handler,
+		},
+		{
+			MethodName: "CreateFlashSale",
+			Handler:    _ShopService_CreateFlashSale_Handler,
+		},
+		{
+			MethodName: "ListActiveFlashSales",
+			Handler:    _ShopService_ListActiveFlashSales_Handler,
+		},
+		{
+			MethodName: "GetEffectivePrice",
+			Handler:    _ShopService_GetEffectivePrice_Handler,
+		},
+		{
+			MethodName: "SetOpeningHours",
+			Handler:    _ShopService_SetOpeningHours_Handler,
+		},
+		{
+			MethodName: "SetVacation",
+			Handler:    _ShopService_SetVacation_Handler,
+		},
+		{
+			MethodName: "GetShopAvailability",
+			Handler:    _ShopService_GetShopAvailability_Handler,
+		},
+		{
+			MethodName: "RateShop",
+			Handler:    _ShopService_RateShop_Handler,
+		},
+		{
+			MethodName: "ListShopReviews",
+			Handler:    _ShopService_ListShopReviews_Handler,
+		},
+		{
+			MethodName: "ReplyShopReview",
+			Handler:    _ShopService_ReplyShopReview_Handler,
+		},
 		{
 			MethodName: "UpdateShopName",
 			Handler:    _ShopService_UpdateShopName_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UpdateShopAvatar",
+			Handler:       _ShopService_UpdateShopAvatar_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _ShopService_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _ShopService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchStockAlerts",
+			Handler:       _ShopService_WatchStockAlerts_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "shop_service.proto",
 }
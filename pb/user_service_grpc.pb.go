@@ -39,6 +39,7 @@ type UserServiceClient interface {
 	ForgotPassword(ctx context.Context, in *ForgotPasswordRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
 	SupplierRegister(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GeneralResponse, error)
 	SupplierReport(ctx context.Context, in *SupplierReportRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
+	SupplierRevoke(ctx context.Context, in *SupplierRevokeRequest, opts ...grpc.CallOption) (*GeneralResponse, error)
 }
 
 type userServiceClient struct {
@@ -193,6 +194,15 @@ func (c *userServiceClient) SupplierReport(ctx context.Context, in *SupplierRepo
 	return out, nil
 }
 
+func (c *userServiceClient) SupplierRevoke(ctx context.Context, in *SupplierRevokeRequest, opts ...grpc.CallOption) (*GeneralResponse, error) {
+	out := new(GeneralResponse)
+	err := c.cc.Invoke(ctx, "/ecommerce.UserService/SupplierRevoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -213,6 +223,7 @@ type UserServiceServer interface {
 	ForgotPassword(context.Context, *ForgotPasswordRequest) (*GeneralResponse, error)
 	SupplierRegister(context.Context, *empty.Empty) (*GeneralResponse, error)
 	SupplierReport(context.Context, *SupplierReportRequest) (*GeneralResponse, error)
+	SupplierRevoke(context.Context, *SupplierRevokeRequest) (*GeneralResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -268,6 +279,9 @@ func (UnimplementedUserServiceServer) SupplierRegister(context.Context, *empty.E
 func (UnimplementedUserServiceServer) SupplierReport(context.Context, *SupplierReportRequest) (*GeneralResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SupplierReport not implemented")
 }
+func (UnimplementedUserServiceServer) SupplierRevoke(context.Context, *SupplierRevokeRequest) (*GeneralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SupplierRevoke not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -569,6 +583,24 @@ func _UserService_SupplierReport_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_SupplierRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SupplierRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ecommerce.UserService/SupplierRevoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SupplierRevoke(ctx, req.(*SupplierRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -640,6 +672,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SupplierReport",
 			Handler:    _UserService_SupplierReport_Handler,
 		},
+		{
+			MethodName: "SupplierRevoke",
+			Handler:    _UserService_SupplierRevoke_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user_service.proto",
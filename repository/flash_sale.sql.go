@@ -0,0 +1,352 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: flash_sale.sql
+
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const claimFlashSalesToEnd = `-- name: ClaimFlashSalesToEnd :many
+UPDATE flash_sale
+SET "status" = 'sale_ending', "updated_at" = now()
+WHERE "id" IN (
+    SELECT due."id" FROM flash_sale AS due
+    WHERE (due."status" = 'sale_running' AND due."ends_at" <= now())
+        -- interrupted by a crash
+        OR (due."status" IN ('sale_starting', 'sale_ending') AND due."updated_at" < $1)
+    ORDER BY due."ends_at"
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, shop_id, seller_id, product_id, sale_price, quantity, starts_at, ends_at, status, original_price, price_applied, withheld, last_error, created_at, updated_at, withholding, withhold_inventory, withhold_sold
+`
+
+type ClaimFlashSalesToEndParams struct {
+	StaleBefore time.Time
+	RowLimit    int32
+}
+
+func (q *Queries) ClaimFlashSalesToEnd(ctx context.Context, arg ClaimFlashSalesToEndParams) ([]FlashSale, error) {
+	rows, err := q.db.QueryContext(ctx, claimFlashSalesToEnd, arg.StaleBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FlashSale
+	for rows.Next() {
+		var i FlashSale
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.SellerID,
+			&i.ProductID,
+			&i.SalePrice,
+			&i.Quantity,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Status,
+			&i.OriginalPrice,
+			&i.PriceApplied,
+			&i.Withheld,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Withholding,
+			&i.WithholdInventory,
+			&i.WithholdSold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimFlashSalesToStart = `-- name: ClaimFlashSalesToStart :many
+UPDATE flash_sale
+SET "status" = 'sale_starting', "updated_at" = now()
+WHERE "id" IN (
+    SELECT due."id" FROM flash_sale AS due
+    WHERE due."status" = 'sale_scheduled' AND due."starts_at" <= now() AND due."ends_at" > now()
+    ORDER BY due."starts_at"
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, shop_id, seller_id, product_id, sale_price, quantity, starts_at, ends_at, status, original_price, price_applied, withheld, last_error, created_at, updated_at, withholding, withhold_inventory, withhold_sold
+`
+
+func (q *Queries) ClaimFlashSalesToStart(ctx context.Context, rowLimit int32) ([]FlashSale, error) {
+	rows, err := q.db.QueryContext(ctx, claimFlashSalesToStart, rowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FlashSale
+	for rows.Next() {
+		var i FlashSale
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.SellerID,
+			&i.ProductID,
+			&i.SalePrice,
+			&i.Quantity,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Status,
+			&i.OriginalPrice,
+			&i.PriceApplied,
+			&i.Withheld,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Withholding,
+			&i.WithholdInventory,
+			&i.WithholdSold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createFlashSale = `-- name: CreateFlashSale :one
+INSERT INTO flash_sale ("shop_id", "seller_id", "product_id", "sale_price", "quantity", "starts_at", "ends_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, shop_id, seller_id, product_id, sale_price, quantity, starts_at, ends_at, status, original_price, price_applied, withheld, last_error, created_at, updated_at, withholding, withhold_inventory, withhold_sold
+`
+
+type CreateFlashSaleParams struct {
+	ShopID    int64
+	SellerID  int64
+	ProductID int64
+	SalePrice int64
+	Quantity  int64
+	StartsAt  time.Time
+	EndsAt    time.Time
+}
+
+func (q *Queries) CreateFlashSale(ctx context.Context, arg CreateFlashSaleParams) (FlashSale, error) {
+	row := q.db.QueryRowContext(ctx, createFlashSale,
+		arg.ShopID,
+		arg.SellerID,
+		arg.ProductID,
+		arg.SalePrice,
+		arg.Quantity,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var i FlashSale
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.ProductID,
+		&i.SalePrice,
+		&i.Quantity,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Status,
+		&i.OriginalPrice,
+		&i.PriceApplied,
+		&i.Withheld,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Withholding,
+		&i.WithholdInventory,
+		&i.WithholdSold,
+	)
+	return i, err
+}
+
+const failMissedFlashSales = `-- name: FailMissedFlashSales :execrows
+UPDATE flash_sale
+SET "status" = 'sale_failed', "last_error" = $1, "updated_at" = now()
+WHERE "status" = 'sale_scheduled' AND "ends_at" <= now()
+`
+
+func (q *Queries) FailMissedFlashSales(ctx context.Context, lastError string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failMissedFlashSales, lastError)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const finishFlashSale = `-- name: FinishFlashSale :exec
+UPDATE flash_sale
+SET "status" = $2, "last_error" = $3, "updated_at" = now()
+WHERE "id" = $1
+`
+
+type FinishFlashSaleParams struct {
+	ID        int64
+	Status    string
+	LastError string
+}
+
+func (q *Queries) FinishFlashSale(ctx context.Context, arg FinishFlashSaleParams) error {
+	_, err := q.db.ExecContext(ctx, finishFlashSale, arg.ID, arg.Status, arg.LastError)
+	return err
+}
+
+const getRunningFlashSaleByProduct = `-- name: GetRunningFlashSaleByProduct :one
+SELECT id, shop_id, seller_id, product_id, sale_price, quantity, starts_at, ends_at, status, original_price, price_applied, withheld, last_error, created_at, updated_at, withholding, withhold_inventory, withhold_sold FROM flash_sale WHERE "product_id" = $1 AND "status" = 'sale_running'
+`
+
+func (q *Queries) GetRunningFlashSaleByProduct(ctx context.Context, productID int64) (FlashSale, error) {
+	row := q.db.QueryRowContext(ctx, getRunningFlashSaleByProduct, productID)
+	var i FlashSale
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.ProductID,
+		&i.SalePrice,
+		&i.Quantity,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Status,
+		&i.OriginalPrice,
+		&i.PriceApplied,
+		&i.Withheld,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Withholding,
+		&i.WithholdInventory,
+		&i.WithholdSold,
+	)
+	return i, err
+}
+
+const listRunningFlashSales = `-- name: ListRunningFlashSales :many
+SELECT id, shop_id, seller_id, product_id, sale_price, quantity, starts_at, ends_at, status, original_price, price_applied, withheld, last_error, created_at, updated_at, withholding, withhold_inventory, withhold_sold FROM flash_sale
+WHERE "status" = 'sale_running'
+    AND ($1::int8 = 0 OR "shop_id" = $1::int8)
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListRunningFlashSalesParams struct {
+	ShopID   int64
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListRunningFlashSales(ctx context.Context, arg ListRunningFlashSalesParams) ([]FlashSale, error) {
+	rows, err := q.db.QueryContext(ctx, listRunningFlashSales, arg.ShopID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FlashSale
+	for rows.Next() {
+		var i FlashSale
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.SellerID,
+			&i.ProductID,
+			&i.SalePrice,
+			&i.Quantity,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Status,
+			&i.OriginalPrice,
+			&i.PriceApplied,
+			&i.Withheld,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Withholding,
+			&i.WithholdInventory,
+			&i.WithholdSold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordFlashSalePrice = `-- name: RecordFlashSalePrice :exec
+UPDATE flash_sale
+SET "price_applied" = $2, "original_price" = $3, "updated_at" = now()
+WHERE "id" = $1
+`
+
+type RecordFlashSalePriceParams struct {
+	ID            int64
+	PriceApplied  bool
+	OriginalPrice int64
+}
+
+func (q *Queries) RecordFlashSalePrice(ctx context.Context, arg RecordFlashSalePriceParams) error {
+	_, err := q.db.ExecContext(ctx, recordFlashSalePrice, arg.ID, arg.PriceApplied, arg.OriginalPrice)
+	return err
+}
+
+const recordFlashSaleWithheld = `-- name: RecordFlashSaleWithheld :exec
+UPDATE flash_sale
+SET "withheld" = $2, "withholding" = 0, "updated_at" = now()
+WHERE "id" = $1
+`
+
+type RecordFlashSaleWithheldParams struct {
+	ID       int64
+	Withheld int64
+}
+
+func (q *Queries) RecordFlashSaleWithheld(ctx context.Context, arg RecordFlashSaleWithheldParams) error {
+	_, err := q.db.ExecContext(ctx, recordFlashSaleWithheld, arg.ID, arg.Withheld)
+	return err
+}
+
+const recordFlashSaleWithholding = `-- name: RecordFlashSaleWithholding :exec
+UPDATE flash_sale
+SET "withholding" = $2, "withhold_inventory" = $3, "withhold_sold" = $4, "updated_at" = now()
+WHERE "id" = $1
+`
+
+type RecordFlashSaleWithholdingParams struct {
+	ID                int64
+	Withholding       int64
+	WithholdInventory int64
+	WithholdSold      int64
+}
+
+func (q *Queries) RecordFlashSaleWithholding(ctx context.Context, arg RecordFlashSaleWithholdingParams) error {
+	_, err := q.db.ExecContext(ctx, recordFlashSaleWithholding,
+		arg.ID,
+		arg.Withholding,
+		arg.WithholdInventory,
+		arg.WithholdSold,
+	)
+	return err
+}
@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: inventory_adjustment.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const createInventoryAdjustment = `-- name: CreateInventoryAdjustment :exec
+INSERT INTO inventory_adjustment ("product_id", "actor_id", "delta", "reason") VALUES ($1, $2, $3, $4)
+`
+
+type CreateInventoryAdjustmentParams struct {
+	ProductID int64
+	ActorID   int64
+	Delta     int32
+	Reason    string
+}
+
+func (q *Queries) CreateInventoryAdjustment(ctx context.Context, arg CreateInventoryAdjustmentParams) error {
+	_, err := q.db.ExecContext(ctx, createInventoryAdjustment,
+		arg.ProductID,
+		arg.ActorID,
+		arg.Delta,
+		arg.Reason,
+	)
+	return err
+}
+
+const listInventoryAdjustments = `-- name: ListInventoryAdjustments :many
+SELECT id, product_id, actor_id, delta, reason, created_at FROM inventory_adjustment
+WHERE "product_id" = $1
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListInventoryAdjustmentsParams struct {
+	ProductID int64
+	Cursor    int64
+	RowLimit  int32
+}
+
+func (q *Queries) ListInventoryAdjustments(ctx context.Context, arg ListInventoryAdjustmentsParams) ([]InventoryAdjustment, error) {
+	rows, err := q.db.QueryContext(ctx, listInventoryAdjustments, arg.ProductID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InventoryAdjustment
+	for rows.Next() {
+		var i InventoryAdjustment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ActorID,
+			&i.Delta,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -9,10 +9,198 @@ import (
 	"time"
 )
 
+type FlashSale struct {
+	ID                int64
+	ShopID            int64
+	SellerID          int64
+	ProductID         int64
+	SalePrice         int64
+	Quantity          int64
+	StartsAt          time.Time
+	EndsAt            time.Time
+	Status            string
+	OriginalPrice     int64
+	PriceApplied      bool
+	Withheld          int64
+	LastError         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Withholding       int64
+	WithholdInventory int64
+	WithholdSold      int64
+}
+
+type InventoryAdjustment struct {
+	ID        int64
+	ProductID int64
+	ActorID   int64
+	Delta     int32
+	Reason    string
+	CreatedAt time.Time
+}
+
+type ProductDraft struct {
+	ID            int64
+	ShopID        int64
+	SellerID      int64
+	CategoryID    int64
+	Name          string
+	Description   string
+	Price         int64
+	Thumbnail     string
+	Inventory     int64
+	Brand         string
+	Status        string
+	PublishAt     sql.NullTime
+	Attempts      int32
+	NextAttemptAt sql.NullTime
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type Shop struct {
+	ID                        int64
+	SellerID                  int64
+	Name                      string
+	Avatar                    sql.NullString
+	CreatedAt                 time.Time
+	Description               string
+	IsOfficial                bool
+	Banner                    string
+	ContactPhone              string
+	ContactEmail              string
+	Address                   string
+	Status                    string
+	Timezone                  string
+	VacationStart             sql.NullTime
+	VacationEnd               sql.NullTime
+	VacationMessage           string
+	ReviewCount               int64
+	ReviewRatingSum           int64
+	ReportForwardPendingSince sql.NullTime
+}
+
+type ShopCategory struct {
+	ShopID     int64
+	CategoryID int64
+}
+
+type ShopCollection struct {
+	ID          int64
+	ShopID      int64
+	Name        string
+	Description string
+	Position    int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ShopCollectionItem struct {
+	CollectionID int64
+	ProductID    int64
+	Position     int32
+}
+
+type ShopFollower struct {
+	ID        int64
+	ShopID    int64
+	UserID    int64
+	CreatedAt time.Time
+}
+
+type ShopModerationLog struct {
+	ID         int64
+	ShopID     int64
+	AdminID    int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+type ShopOpeningHour struct {
+	ShopID   int64
+	Weekday  int16
+	OpensAt  int32
+	ClosesAt int32
+}
+
+type ShopRegistrationSaga struct {
+	ID              int64
+	SellerID        int64
+	Name            string
+	Avatar          sql.NullString
+	State           string
+	Attempts        int32
+	LastError       sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	CategoryIds     []int64
+	RolePreexisting bool
+}
+
+type ShopReport struct {
+	ID         int64
+	ShopID     int64
+	ReporterID int64
+	Reason     string
+	Detail     string
+	Resolved   bool
+	CreatedAt  time.Time
+}
+
+type ShopReview struct {
+	ID        int64
+	ShopID    int64
+	UserID    int64
+	Rating    int16
+	Comment   string
+	Reply     string
+	RepliedAt sql.NullTime
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ShopVoucher struct {
+	ID            int64
+	ShopID        int64
+	Code          string
+	Kind          string
+	Value         int64
+	MinOrderValue int64
+	UsageLimit    int32
+	PerUserLimit  int32
+	UsedCount     int32
+	StartsAt      time.Time
+	EndsAt        time.Time
+	CreatedAt     time.Time
+}
+
+type StockAlert struct {
+	ID          int64
+	ShopID      int64
+	ProductID   int64
+	ProductName string
+	Inventory   int64
+	Threshold   int32
+	Resolved    bool
+	CreatedAt   time.Time
+	ResolvedAt  sql.NullTime
+}
+
+type StockThreshold struct {
+	ShopID    int64
+	ProductID int64
+	Threshold int32
+}
+
+type VoucherRedemption struct {
 	ID        int64
-	SellerID  int64
-	Name      string
-	Avatar    sql.NullString
+	VoucherID int64
+	UserID    int64
+	OrderID   int64
+	Subtotal  int64
+	Discount  int64
 	CreatedAt time.Time
 }
@@ -0,0 +1,487 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: product_draft.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const claimDueProductDrafts = `-- name: ClaimDueProductDrafts :many
+UPDATE product_draft
+SET "status" = 'publishing', "attempts" = "attempts" + 1, "updated_at" = now()
+WHERE "id" IN (
+    SELECT "id" FROM product_draft
+    WHERE "status" = 'scheduled' AND "next_attempt_at" <= now()
+    ORDER BY "next_attempt_at"
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+func (q *Queries) ClaimDueProductDrafts(ctx context.Context, rowLimit int32) ([]ProductDraft, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueProductDrafts, rowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductDraft
+	for rows.Next() {
+		var i ProductDraft
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.SellerID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Thumbnail,
+			&i.Inventory,
+			&i.Brand,
+			&i.Status,
+			&i.PublishAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimProductDraft = `-- name: ClaimProductDraft :one
+UPDATE product_draft
+SET "status" = 'publishing', "attempts" = "attempts" + 1, "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type ClaimProductDraftParams struct {
+	ID       int64
+	SellerID int64
+}
+
+func (q *Queries) ClaimProductDraft(ctx context.Context, arg ClaimProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, claimProductDraft, arg.ID, arg.SellerID)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createProductDraft = `-- name: CreateProductDraft :one
+INSERT INTO product_draft ("shop_id", "seller_id", "category_id", "name", "description", "price", "thumbnail", "inventory", "brand")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type CreateProductDraftParams struct {
+	ShopID      int64
+	SellerID    int64
+	CategoryID  int64
+	Name        string
+	Description string
+	Price       int64
+	Thumbnail   string
+	Inventory   int64
+	Brand       string
+}
+
+func (q *Queries) CreateProductDraft(ctx context.Context, arg CreateProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, createProductDraft,
+		arg.ShopID,
+		arg.SellerID,
+		arg.CategoryID,
+		arg.Name,
+		arg.Description,
+		arg.Price,
+		arg.Thumbnail,
+		arg.Inventory,
+		arg.Brand,
+	)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const failStaleProductDrafts = `-- name: FailStaleProductDrafts :execrows
+UPDATE product_draft
+SET "status" = 'publish_failed', "last_error" = $1, "updated_at" = now()
+WHERE "status" = 'publishing' AND "updated_at" < $2
+`
+
+type FailStaleProductDraftsParams struct {
+	LastError   string
+	StaleBefore time.Time
+}
+
+func (q *Queries) FailStaleProductDrafts(ctx context.Context, arg FailStaleProductDraftsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failStaleProductDrafts, arg.LastError, arg.StaleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const finishProductDraft = `-- name: FinishProductDraft :one
+UPDATE product_draft
+SET "status" = $1, "next_attempt_at" = $2, "last_error" = $3, "updated_at" = now()
+WHERE "id" = $4 AND "status" = 'publishing'
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type FinishProductDraftParams struct {
+	Status        string
+	NextAttemptAt sql.NullTime
+	LastError     string
+	ID            int64
+}
+
+func (q *Queries) FinishProductDraft(ctx context.Context, arg FinishProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, finishProductDraft,
+		arg.Status,
+		arg.NextAttemptAt,
+		arg.LastError,
+		arg.ID,
+	)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductDraft = `-- name: GetProductDraft :one
+SELECT id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at FROM product_draft WHERE "id" = $1 AND "seller_id" = $2
+`
+
+type GetProductDraftParams struct {
+	ID       int64
+	SellerID int64
+}
+
+func (q *Queries) GetProductDraft(ctx context.Context, arg GetProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, getProductDraft, arg.ID, arg.SellerID)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listProductDrafts = `-- name: ListProductDrafts :many
+SELECT id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at FROM product_draft
+WHERE "seller_id" = $1
+    AND (cardinality($2::varchar[]) = 0 OR "status" = ANY($2::varchar[]))
+    AND ($3::int8 = 0 OR "id" < $3::int8)
+ORDER BY "id" DESC
+LIMIT $4
+`
+
+type ListProductDraftsParams struct {
+	SellerID int64
+	Statuses []string
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListProductDrafts(ctx context.Context, arg ListProductDraftsParams) ([]ProductDraft, error) {
+	rows, err := q.db.QueryContext(ctx, listProductDrafts,
+		arg.SellerID,
+		pq.Array(arg.Statuses),
+		arg.Cursor,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductDraft
+	for rows.Next() {
+		var i ProductDraft
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.SellerID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Thumbnail,
+			&i.Inventory,
+			&i.Brand,
+			&i.Status,
+			&i.PublishAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const postponeProductDraft = `-- name: PostponeProductDraft :one
+UPDATE product_draft
+SET "status" = 'scheduled', "next_attempt_at" = $2, "attempts" = "attempts" - 1, "updated_at" = now()
+WHERE "id" = $1 AND "status" = 'publishing'
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type PostponeProductDraftParams struct {
+	ID            int64
+	NextAttemptAt sql.NullTime
+}
+
+func (q *Queries) PostponeProductDraft(ctx context.Context, arg PostponeProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, postponeProductDraft, arg.ID, arg.NextAttemptAt)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const scheduleProductDraft = `-- name: ScheduleProductDraft :one
+UPDATE product_draft
+SET "status" = 'scheduled', "publish_at" = $1, "next_attempt_at" = $1,
+    "attempts" = 0, "last_error" = '', "updated_at" = now()
+WHERE "id" = $2 AND "seller_id" = $3 AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type ScheduleProductDraftParams struct {
+	PublishAt sql.NullTime
+	ID        int64
+	SellerID  int64
+}
+
+func (q *Queries) ScheduleProductDraft(ctx context.Context, arg ScheduleProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, scheduleProductDraft, arg.PublishAt, arg.ID, arg.SellerID)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const unscheduleProductDraft = `-- name: UnscheduleProductDraft :one
+UPDATE product_draft
+SET "status" = 'draft', "publish_at" = NULL, "next_attempt_at" = NULL, "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" = 'scheduled'
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type UnscheduleProductDraftParams struct {
+	ID       int64
+	SellerID int64
+}
+
+func (q *Queries) UnscheduleProductDraft(ctx context.Context, arg UnscheduleProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, unscheduleProductDraft, arg.ID, arg.SellerID)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProductDraft = `-- name: UpdateProductDraft :one
+UPDATE product_draft
+SET "category_id" = $3, "name" = $4, "description" = $5, "price" = $6, "thumbnail" = $7, "inventory" = $8, "brand" = $9,
+    -- editing a failed draft starts it over
+    "status" = CASE WHEN "status" = 'publish_failed' THEN 'draft' ELSE "status" END,
+    "updated_at" = now()
+WHERE "id" = $1 AND "seller_id" = $2 AND "status" IN ('draft', 'scheduled', 'publish_failed')
+RETURNING id, shop_id, seller_id, category_id, name, description, price, thumbnail, inventory, brand, status, publish_at, attempts, next_attempt_at, last_error, created_at, updated_at
+`
+
+type UpdateProductDraftParams struct {
+	ID          int64
+	SellerID    int64
+	CategoryID  int64
+	Name        string
+	Description string
+	Price       int64
+	Thumbnail   string
+	Inventory   int64
+	Brand       string
+}
+
+func (q *Queries) UpdateProductDraft(ctx context.Context, arg UpdateProductDraftParams) (ProductDraft, error) {
+	row := q.db.QueryRowContext(ctx, updateProductDraft,
+		arg.ID,
+		arg.SellerID,
+		arg.CategoryID,
+		arg.Name,
+		arg.Description,
+		arg.Price,
+		arg.Thumbnail,
+		arg.Inventory,
+		arg.Brand,
+	)
+	var i ProductDraft
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.SellerID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Thumbnail,
+		&i.Inventory,
+		&i.Brand,
+		&i.Status,
+		&i.PublishAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
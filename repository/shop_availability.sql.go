@@ -0,0 +1,137 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_availability.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addShopOpeningHours = `-- name: AddShopOpeningHours :exec
+INSERT INTO shop_opening_hours ("shop_id", "weekday", "opens_at", "closes_at") VALUES ($1, $2, $3, $4)
+`
+
+type AddShopOpeningHoursParams struct {
+	ShopID   int64
+	Weekday  int16
+	OpensAt  int32
+	ClosesAt int32
+}
+
+func (q *Queries) AddShopOpeningHours(ctx context.Context, arg AddShopOpeningHoursParams) error {
+	_, err := q.db.ExecContext(ctx, addShopOpeningHours,
+		arg.ShopID,
+		arg.Weekday,
+		arg.OpensAt,
+		arg.ClosesAt,
+	)
+	return err
+}
+
+const clearShopOpeningHours = `-- name: ClearShopOpeningHours :exec
+DELETE FROM shop_opening_hours WHERE "shop_id" = $1
+`
+
+func (q *Queries) ClearShopOpeningHours(ctx context.Context, shopID int64) error {
+	_, err := q.db.ExecContext(ctx, clearShopOpeningHours, shopID)
+	return err
+}
+
+const listShopOpeningHours = `-- name: ListShopOpeningHours :many
+SELECT shop_id, weekday, opens_at, closes_at FROM shop_opening_hours
+WHERE "shop_id" = $1
+ORDER BY "weekday", "opens_at"
+`
+
+func (q *Queries) ListShopOpeningHours(ctx context.Context, shopID int64) ([]ShopOpeningHour, error) {
+	rows, err := q.db.QueryContext(ctx, listShopOpeningHours, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShopOpeningHour
+	for rows.Next() {
+		var i ShopOpeningHour
+		if err := rows.Scan(
+			&i.ShopID,
+			&i.Weekday,
+			&i.OpensAt,
+			&i.ClosesAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateShopTimezone = `-- name: UpdateShopTimezone :exec
+UPDATE "shop"
+SET "timezone" = $2
+WHERE "id" = $1
+`
+
+type UpdateShopTimezoneParams struct {
+	ID       int64
+	Timezone string
+}
+
+func (q *Queries) UpdateShopTimezone(ctx context.Context, arg UpdateShopTimezoneParams) error {
+	_, err := q.db.ExecContext(ctx, updateShopTimezone, arg.ID, arg.Timezone)
+	return err
+}
+
+const updateShopVacation = `-- name: UpdateShopVacation :one
+UPDATE "shop"
+SET "vacation_start" = $2, "vacation_end" = $3, "vacation_message" = $4
+WHERE "id" = $1
+RETURNING id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since
+`
+
+type UpdateShopVacationParams struct {
+	ID              int64
+	VacationStart   sql.NullTime
+	VacationEnd     sql.NullTime
+	VacationMessage string
+}
+
+func (q *Queries) UpdateShopVacation(ctx context.Context, arg UpdateShopVacationParams) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, updateShopVacation,
+		arg.ID,
+		arg.VacationStart,
+		arg.VacationEnd,
+		arg.VacationMessage,
+	)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
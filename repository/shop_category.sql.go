@@ -0,0 +1,116 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_category.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const addShopCategories = `-- name: AddShopCategories :exec
+INSERT INTO shop_category ("shop_id", "category_id")
+SELECT $1, unnest($2::int8[])
+ON CONFLICT DO NOTHING
+`
+
+type AddShopCategoriesParams struct {
+	ShopID      int64
+	CategoryIds []int64
+}
+
+func (q *Queries) AddShopCategories(ctx context.Context, arg AddShopCategoriesParams) error {
+	_, err := q.db.ExecContext(ctx, addShopCategories, arg.ShopID, pq.Array(arg.CategoryIds))
+	return err
+}
+
+const listShopCategoryIDs = `-- name: ListShopCategoryIDs :many
+SELECT "category_id" FROM shop_category
+WHERE "shop_id" = $1
+ORDER BY "category_id"
+`
+
+func (q *Queries) ListShopCategoryIDs(ctx context.Context, shopID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listShopCategoryIDs, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var category_id int64
+		if err := rows.Scan(&category_id); err != nil {
+			return nil, err
+		}
+		items = append(items, category_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShopsByCategory = `-- name: ListShopsByCategory :many
+SELECT shop.id, shop.seller_id, shop.name, shop.avatar, shop.created_at, shop.description, shop.is_official, shop.banner, shop.contact_phone, shop.contact_email, shop.address, shop.status, shop.timezone, shop.vacation_start, shop.vacation_end, shop.vacation_message, shop.review_count, shop.review_rating_sum, shop.report_forward_pending_since FROM shop
+JOIN shop_category ON shop_category."shop_id" = shop."id"
+WHERE shop_category."category_id" = $1
+    AND shop."status" NOT IN ('suspended', 'closed')
+    AND ($2::int8 = 0 OR shop."id" < $2::int8)
+ORDER BY shop."id" DESC
+LIMIT $3
+`
+
+type ListShopsByCategoryParams struct {
+	CategoryID int64
+	Cursor     int64
+	RowLimit   int32
+}
+
+func (q *Queries) ListShopsByCategory(ctx context.Context, arg ListShopsByCategoryParams) ([]Shop, error) {
+	rows, err := q.db.QueryContext(ctx, listShopsByCategory, arg.CategoryID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shop
+	for rows.Next() {
+		var i Shop
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Avatar,
+			&i.CreatedAt,
+			&i.Description,
+			&i.IsOfficial,
+			&i.Banner,
+			&i.ContactPhone,
+			&i.ContactEmail,
+			&i.Address,
+			&i.Status,
+			&i.Timezone,
+			&i.VacationStart,
+			&i.VacationEnd,
+			&i.VacationMessage,
+			&i.ReviewCount,
+			&i.ReviewRatingSum,
+			&i.ReportForwardPendingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,248 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_collection.sql
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const addShopCollectionItem = `-- name: AddShopCollectionItem :exec
+INSERT INTO shop_collection_item ("collection_id", "product_id", "position") VALUES ($1, $2, $3)
+`
+
+type AddShopCollectionItemParams struct {
+	CollectionID int64
+	ProductID    int64
+	Position     int32
+}
+
+func (q *Queries) AddShopCollectionItem(ctx context.Context, arg AddShopCollectionItemParams) error {
+	_, err := q.db.ExecContext(ctx, addShopCollectionItem, arg.CollectionID, arg.ProductID, arg.Position)
+	return err
+}
+
+const clearShopCollectionItems = `-- name: ClearShopCollectionItems :exec
+DELETE FROM shop_collection_item WHERE "collection_id" = $1
+`
+
+func (q *Queries) ClearShopCollectionItems(ctx context.Context, collectionID int64) error {
+	_, err := q.db.ExecContext(ctx, clearShopCollectionItems, collectionID)
+	return err
+}
+
+const countShopCollections = `-- name: CountShopCollections :one
+SELECT count(*) FROM shop_collection WHERE "shop_id" = $1
+`
+
+func (q *Queries) CountShopCollections(ctx context.Context, shopID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countShopCollections, shopID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createShopCollection = `-- name: CreateShopCollection :one
+INSERT INTO shop_collection ("shop_id", "name", "description", "position") VALUES ($1, $2, $3, $4)
+RETURNING id, shop_id, name, description, position, created_at, updated_at
+`
+
+type CreateShopCollectionParams struct {
+	ShopID      int64
+	Name        string
+	Description string
+	Position    int32
+}
+
+func (q *Queries) CreateShopCollection(ctx context.Context, arg CreateShopCollectionParams) (ShopCollection, error) {
+	row := q.db.QueryRowContext(ctx, createShopCollection,
+		arg.ShopID,
+		arg.Name,
+		arg.Description,
+		arg.Position,
+	)
+	var i ShopCollection
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Name,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteShopCollection = `-- name: DeleteShopCollection :execrows
+DELETE FROM shop_collection WHERE "id" = $1 AND "shop_id" = $2
+`
+
+type DeleteShopCollectionParams struct {
+	ID     int64
+	ShopID int64
+}
+
+func (q *Queries) DeleteShopCollection(ctx context.Context, arg DeleteShopCollectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteShopCollection, arg.ID, arg.ShopID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getShopCollection = `-- name: GetShopCollection :one
+SELECT id, shop_id, name, description, position, created_at, updated_at FROM shop_collection WHERE "id" = $1
+`
+
+func (q *Queries) GetShopCollection(ctx context.Context, id int64) (ShopCollection, error) {
+	row := q.db.QueryRowContext(ctx, getShopCollection, id)
+	var i ShopCollection
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Name,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listShopCollectionItems = `-- name: ListShopCollectionItems :many
+SELECT "product_id" FROM shop_collection_item
+WHERE "collection_id" = $1
+ORDER BY "position", "product_id"
+`
+
+func (q *Queries) ListShopCollectionItems(ctx context.Context, collectionID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listShopCollectionItems, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var product_id int64
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShopCollections = `-- name: ListShopCollections :many
+SELECT shop_collection.id, shop_collection.shop_id, shop_collection.name, shop_collection.description, shop_collection.position, shop_collection.created_at, shop_collection.updated_at, (
+    SELECT count(*) FROM shop_collection_item WHERE shop_collection_item."collection_id" = shop_collection."id"
+)::int8 AS item_count
+FROM shop_collection
+WHERE "shop_id" = $1
+ORDER BY "position", "id"
+`
+
+type ListShopCollectionsRow struct {
+	ID          int64
+	ShopID      int64
+	Name        string
+	Description string
+	Position    int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ItemCount   int64
+}
+
+func (q *Queries) ListShopCollections(ctx context.Context, shopID int64) ([]ListShopCollectionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listShopCollections, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListShopCollectionsRow
+	for rows.Next() {
+		var i ListShopCollectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.Name,
+			&i.Description,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeShopCollectionItems = `-- name: RemoveShopCollectionItems :exec
+DELETE FROM shop_collection_item
+WHERE "collection_id" = $1 AND "product_id" = ANY($2::int8[])
+`
+
+type RemoveShopCollectionItemsParams struct {
+	CollectionID int64
+	ProductIds   []int64
+}
+
+func (q *Queries) RemoveShopCollectionItems(ctx context.Context, arg RemoveShopCollectionItemsParams) error {
+	_, err := q.db.ExecContext(ctx, removeShopCollectionItems, arg.CollectionID, pq.Array(arg.ProductIds))
+	return err
+}
+
+const updateShopCollection = `-- name: UpdateShopCollection :one
+UPDATE shop_collection
+SET "name" = $3, "description" = $4, "position" = $5, "updated_at" = now()
+WHERE "id" = $1 AND "shop_id" = $2
+RETURNING id, shop_id, name, description, position, created_at, updated_at
+`
+
+type UpdateShopCollectionParams struct {
+	ID          int64
+	ShopID      int64
+	Name        string
+	Description string
+	Position    int32
+}
+
+func (q *Queries) UpdateShopCollection(ctx context.Context, arg UpdateShopCollectionParams) (ShopCollection, error) {
+	row := q.db.QueryRowContext(ctx, updateShopCollection,
+		arg.ID,
+		arg.ShopID,
+		arg.Name,
+		arg.Description,
+		arg.Position,
+	)
+	var i ShopCollection
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Name,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
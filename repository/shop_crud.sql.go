@@ -10,8 +10,9 @@ import (
 	"database/sql"
 )
 
-const createShop = `-- name: CreateShop :exec
+const createShop = `-- name: CreateShop :one
 INSERT INTO shop ("seller_id", "name", "avatar") VALUES ($1, $2, $3)
+RETURNING id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since
 `
 
 type CreateShopParams struct {
@@ -20,17 +21,81 @@ type CreateShopParams struct {
 	Avatar   sql.NullString
 }
 
-func (q *Queries) CreateShop(ctx context.Context, arg CreateShopParams) error {
-	_, err := q.db.ExecContext(ctx, createShop, arg.SellerID, arg.Name, arg.Avatar)
+func (q *Queries) CreateShop(ctx context.Context, arg CreateShopParams) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, createShop, arg.SellerID, arg.Name, arg.Avatar)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
+
+const demoteOfficialShop = `-- name: DemoteOfficialShop :exec
+UPDATE "shop"
+SET "is_official" = false
+WHERE "is_official" AND "seller_id" <> $1
+`
+
+func (q *Queries) DemoteOfficialShop(ctx context.Context, sellerID int64) error {
+	_, err := q.db.ExecContext(ctx, demoteOfficialShop, sellerID)
 	return err
 }
 
+const getOfficialShop = `-- name: GetOfficialShop :one
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop WHERE "is_official"
+`
+
+func (q *Queries) GetOfficialShop(ctx context.Context) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, getOfficialShop)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
+
 const getShopByID = `-- name: GetShopByID :one
-SELECT id, seller_id, name, avatar, created_at FROM shop WHERE "seller_id" = $1
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop WHERE "id" = $1
 `
 
-func (q *Queries) GetShopByID(ctx context.Context, sellerID int64) (Shop, error) {
-	row := q.db.QueryRowContext(ctx, getShopByID, sellerID)
+func (q *Queries) GetShopByID(ctx context.Context, id int64) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, getShopByID, id)
 	var i Shop
 	err := row.Scan(
 		&i.ID,
@@ -38,10 +103,92 @@ func (q *Queries) GetShopByID(ctx context.Context, sellerID int64) (Shop, error)
 		&i.Name,
 		&i.Avatar,
 		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
 	)
 	return i, err
 }
 
+const getShopBySellerID = `-- name: GetShopBySellerID :one
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop WHERE "seller_id" = $1
+`
+
+func (q *Queries) GetShopBySellerID(ctx context.Context, sellerID int64) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, getShopBySellerID, sellerID)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
+
+const promoteOfficialShop = `-- name: PromoteOfficialShop :exec
+UPDATE "shop"
+SET "is_official" = true, "name" = $2
+WHERE "seller_id" = $1
+`
+
+type PromoteOfficialShopParams struct {
+	SellerID int64
+	Name     string
+}
+
+func (q *Queries) PromoteOfficialShop(ctx context.Context, arg PromoteOfficialShopParams) error {
+	_, err := q.db.ExecContext(ctx, promoteOfficialShop, arg.SellerID, arg.Name)
+	return err
+}
+
+const updateShopAvatar = `-- name: UpdateShopAvatar :execrows
+UPDATE "shop"
+SET "avatar" = $1
+WHERE "seller_id" = $2
+  AND "avatar" IS NOT DISTINCT FROM $3
+`
+
+type UpdateShopAvatarParams struct {
+	Avatar    sql.NullString
+	SellerID  int64
+	OldAvatar sql.NullString
+}
+
+func (q *Queries) UpdateShopAvatar(ctx context.Context, arg UpdateShopAvatarParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateShopAvatar, arg.Avatar, arg.SellerID, arg.OldAvatar)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateShopName = `-- name: UpdateShopName :exec
 UPDATE "shop"
 SET "name" = $1
@@ -57,3 +204,79 @@ func (q *Queries) UpdateShopName(ctx context.Context, arg UpdateShopNameParams)
 	_, err := q.db.ExecContext(ctx, updateShopName, arg.Name, arg.SellerID)
 	return err
 }
+
+const updateShopProfile = `-- name: UpdateShopProfile :one
+UPDATE "shop"
+SET "name" = COALESCE($1, "name"),
+    "description" = COALESCE($2, "description"),
+    "avatar" = COALESCE($3, "avatar"),
+    "banner" = COALESCE($4, "banner"),
+    "contact_phone" = COALESCE($5, "contact_phone"),
+    "contact_email" = COALESCE($6, "contact_email"),
+    "address" = COALESCE($7, "address")
+WHERE "seller_id" = $8
+RETURNING id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since
+`
+
+type UpdateShopProfileParams struct {
+	Name         sql.NullString
+	Description  sql.NullString
+	Avatar       sql.NullString
+	Banner       sql.NullString
+	ContactPhone sql.NullString
+	ContactEmail sql.NullString
+	Address      sql.NullString
+	SellerID     int64
+}
+
+func (q *Queries) UpdateShopProfile(ctx context.Context, arg UpdateShopProfileParams) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, updateShopProfile,
+		arg.Name,
+		arg.Description,
+		arg.Avatar,
+		arg.Banner,
+		arg.ContactPhone,
+		arg.ContactEmail,
+		arg.Address,
+		arg.SellerID,
+	)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
+
+const upsertOfficialShop = `-- name: UpsertOfficialShop :exec
+INSERT INTO shop ("seller_id", "name", "is_official") VALUES ($1, $2, true)
+ON CONFLICT ("is_official") WHERE "is_official"
+DO UPDATE SET "seller_id" = EXCLUDED."seller_id", "name" = EXCLUDED."name"
+`
+
+type UpsertOfficialShopParams struct {
+	SellerID int64
+	Name     string
+}
+
+func (q *Queries) UpsertOfficialShop(ctx context.Context, arg UpsertOfficialShopParams) error {
+	_, err := q.db.ExecContext(ctx, upsertOfficialShop, arg.SellerID, arg.Name)
+	return err
+}
@@ -0,0 +1,147 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_follower.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const countShopFollowers = `-- name: CountShopFollowers :one
+SELECT count(*) FROM shop_follower WHERE "shop_id" = $1
+`
+
+func (q *Queries) CountShopFollowers(ctx context.Context, shopID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countShopFollowers, shopID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const followShop = `-- name: FollowShop :exec
+INSERT INTO shop_follower ("shop_id", "user_id") VALUES ($1, $2)
+ON CONFLICT ("shop_id", "user_id") DO NOTHING
+`
+
+type FollowShopParams struct {
+	ShopID int64
+	UserID int64
+}
+
+func (q *Queries) FollowShop(ctx context.Context, arg FollowShopParams) error {
+	_, err := q.db.ExecContext(ctx, followShop, arg.ShopID, arg.UserID)
+	return err
+}
+
+const listFollowedShops = `-- name: ListFollowedShops :many
+SELECT shop_follower."id" AS follow_id, shop_follower."created_at" AS followed_at, shop."id", shop."name", shop."avatar"
+FROM shop_follower
+JOIN shop ON shop."id" = shop_follower."shop_id"
+WHERE shop_follower."user_id" = $1
+    AND ($2::int8 = 0 OR shop_follower."id" < $2::int8)
+ORDER BY shop_follower."id" DESC
+LIMIT $3
+`
+
+type ListFollowedShopsParams struct {
+	UserID   int64
+	Cursor   int64
+	RowLimit int32
+}
+
+type ListFollowedShopsRow struct {
+	FollowID   int64
+	FollowedAt time.Time
+	ID         int64
+	Name       string
+	Avatar     sql.NullString
+}
+
+func (q *Queries) ListFollowedShops(ctx context.Context, arg ListFollowedShopsParams) ([]ListFollowedShopsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFollowedShops, arg.UserID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFollowedShopsRow
+	for rows.Next() {
+		var i ListFollowedShopsRow
+		if err := rows.Scan(
+			&i.FollowID,
+			&i.FollowedAt,
+			&i.ID,
+			&i.Name,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShopFollowers = `-- name: ListShopFollowers :many
+SELECT id, shop_id, user_id, created_at FROM shop_follower
+WHERE "shop_id" = $1
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListShopFollowersParams struct {
+	ShopID   int64
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListShopFollowers(ctx context.Context, arg ListShopFollowersParams) ([]ShopFollower, error) {
+	rows, err := q.db.QueryContext(ctx, listShopFollowers, arg.ShopID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShopFollower
+	for rows.Next() {
+		var i ShopFollower
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unfollowShop = `-- name: UnfollowShop :exec
+DELETE FROM shop_follower WHERE "shop_id" = $1 AND "user_id" = $2
+`
+
+type UnfollowShopParams struct {
+	ShopID int64
+	UserID int64
+}
+
+func (q *Queries) UnfollowShop(ctx context.Context, arg UnfollowShopParams) error {
+	_, err := q.db.ExecContext(ctx, unfollowShop, arg.ShopID, arg.UserID)
+	return err
+}
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_moderation.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const createShopModerationLog = `-- name: CreateShopModerationLog :exec
+INSERT INTO shop_moderation_log ("shop_id", "admin_id", "from_status", "to_status", "reason")
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateShopModerationLogParams struct {
+	ShopID     int64
+	AdminID    int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+}
+
+func (q *Queries) CreateShopModerationLog(ctx context.Context, arg CreateShopModerationLogParams) error {
+	_, err := q.db.ExecContext(ctx, createShopModerationLog,
+		arg.ShopID,
+		arg.AdminID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+	)
+	return err
+}
+
+const getShopForUpdate = `-- name: GetShopForUpdate :one
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop WHERE "id" = $1 FOR UPDATE
+`
+
+func (q *Queries) GetShopForUpdate(ctx context.Context, id int64) (Shop, error) {
+	row := q.db.QueryRowContext(ctx, getShopForUpdate, id)
+	var i Shop
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.CreatedAt,
+		&i.Description,
+		&i.IsOfficial,
+		&i.Banner,
+		&i.ContactPhone,
+		&i.ContactEmail,
+		&i.Address,
+		&i.Status,
+		&i.Timezone,
+		&i.VacationStart,
+		&i.VacationEnd,
+		&i.VacationMessage,
+		&i.ReviewCount,
+		&i.ReviewRatingSum,
+		&i.ReportForwardPendingSince,
+	)
+	return i, err
+}
+
+const listShopsByStatus = `-- name: ListShopsByStatus :many
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop
+WHERE "status" = $1
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListShopsByStatusParams struct {
+	Status   string
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListShopsByStatus(ctx context.Context, arg ListShopsByStatusParams) ([]Shop, error) {
+	rows, err := q.db.QueryContext(ctx, listShopsByStatus, arg.Status, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shop
+	for rows.Next() {
+		var i Shop
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Avatar,
+			&i.CreatedAt,
+			&i.Description,
+			&i.IsOfficial,
+			&i.Banner,
+			&i.ContactPhone,
+			&i.ContactEmail,
+			&i.Address,
+			&i.Status,
+			&i.Timezone,
+			&i.VacationStart,
+			&i.VacationEnd,
+			&i.VacationMessage,
+			&i.ReviewCount,
+			&i.ReviewRatingSum,
+			&i.ReportForwardPendingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateShopStatus = `-- name: UpdateShopStatus :exec
+UPDATE "shop"
+SET "status" = $2
+WHERE "id" = $1
+`
+
+type UpdateShopStatusParams struct {
+	ID     int64
+	Status string
+}
+
+func (q *Queries) UpdateShopStatus(ctx context.Context, arg UpdateShopStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateShopStatus, arg.ID, arg.Status)
+	return err
+}
@@ -0,0 +1,170 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_registration_saga.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const createRegistrationSaga = `-- name: CreateRegistrationSaga :one
+INSERT INTO shop_registration_saga ("seller_id", "name", "avatar", "category_ids", "role_preexisting") VALUES ($1, $2, $3, $4, $5)
+RETURNING id, seller_id, name, avatar, state, attempts, last_error, created_at, updated_at, category_ids, role_preexisting
+`
+
+type CreateRegistrationSagaParams struct {
+	SellerID        int64
+	Name            string
+	Avatar          sql.NullString
+	CategoryIds     []int64
+	RolePreexisting bool
+}
+
+func (q *Queries) CreateRegistrationSaga(ctx context.Context, arg CreateRegistrationSagaParams) (ShopRegistrationSaga, error) {
+	row := q.db.QueryRowContext(ctx, createRegistrationSaga,
+		arg.SellerID,
+		arg.Name,
+		arg.Avatar,
+		pq.Array(arg.CategoryIds),
+		arg.RolePreexisting,
+	)
+	var i ShopRegistrationSaga
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.State,
+		&i.Attempts,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.CategoryIds),
+		&i.RolePreexisting,
+	)
+	return i, err
+}
+
+const getActiveRegistrationSaga = `-- name: GetActiveRegistrationSaga :one
+SELECT id, seller_id, name, avatar, state, attempts, last_error, created_at, updated_at, category_ids, role_preexisting FROM shop_registration_saga
+WHERE "seller_id" = $1 AND "state" NOT IN ('done', 'failed', 'abandoned')
+`
+
+func (q *Queries) GetActiveRegistrationSaga(ctx context.Context, sellerID int64) (ShopRegistrationSaga, error) {
+	row := q.db.QueryRowContext(ctx, getActiveRegistrationSaga, sellerID)
+	var i ShopRegistrationSaga
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Avatar,
+		&i.State,
+		&i.Attempts,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.CategoryIds),
+		&i.RolePreexisting,
+	)
+	return i, err
+}
+
+const listStaleRegistrationSagas = `-- name: ListStaleRegistrationSagas :many
+SELECT id, seller_id, name, avatar, state, attempts, last_error, created_at, updated_at, category_ids, role_preexisting FROM shop_registration_saga
+WHERE "state" NOT IN ('done', 'failed', 'abandoned') AND "updated_at" < $1
+ORDER BY "id"
+LIMIT $2
+`
+
+type ListStaleRegistrationSagasParams struct {
+	StaleBefore time.Time
+	RowLimit    int32
+}
+
+func (q *Queries) ListStaleRegistrationSagas(ctx context.Context, arg ListStaleRegistrationSagasParams) ([]ShopRegistrationSaga, error) {
+	rows, err := q.db.QueryContext(ctx, listStaleRegistrationSagas, arg.StaleBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShopRegistrationSaga
+	for rows.Next() {
+		var i ShopRegistrationSaga
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Avatar,
+			&i.State,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			pq.Array(&i.CategoryIds),
+			&i.RolePreexisting,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markRegistrationSagaRolePreexisting = `-- name: MarkRegistrationSagaRolePreexisting :exec
+UPDATE shop_registration_saga
+SET "role_preexisting" = true, "updated_at" = now()
+WHERE "id" = $1
+`
+
+func (q *Queries) MarkRegistrationSagaRolePreexisting(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markRegistrationSagaRolePreexisting, id)
+	return err
+}
+
+const recordRegistrationSagaError = `-- name: RecordRegistrationSagaError :exec
+UPDATE shop_registration_saga
+SET "attempts" = "attempts" + 1, "last_error" = $2, "updated_at" = now()
+WHERE "id" = $1
+`
+
+type RecordRegistrationSagaErrorParams struct {
+	ID        int64
+	LastError sql.NullString
+}
+
+func (q *Queries) RecordRegistrationSagaError(ctx context.Context, arg RecordRegistrationSagaErrorParams) error {
+	_, err := q.db.ExecContext(ctx, recordRegistrationSagaError, arg.ID, arg.LastError)
+	return err
+}
+
+const transitionRegistrationSaga = `-- name: TransitionRegistrationSaga :execrows
+UPDATE shop_registration_saga
+SET "state" = $1, "updated_at" = now()
+WHERE "id" = $2 AND "state" = $3
+`
+
+type TransitionRegistrationSagaParams struct {
+	ToState   string
+	ID        int64
+	FromState string
+}
+
+func (q *Queries) TransitionRegistrationSaga(ctx context.Context, arg TransitionRegistrationSagaParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, transitionRegistrationSaga, arg.ToState, arg.ID, arg.FromState)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
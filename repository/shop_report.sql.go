@@ -0,0 +1,150 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_report.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const clearShopReportForwardPending = `-- name: ClearShopReportForwardPending :exec
+UPDATE "shop"
+SET "report_forward_pending_since" = NULL
+WHERE "id" = $1
+`
+
+func (q *Queries) ClearShopReportForwardPending(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, clearShopReportForwardPending, id)
+	return err
+}
+
+const countOpenShopReporters = `-- name: CountOpenShopReporters :one
+SELECT count(DISTINCT "reporter_id") FROM shop_report
+WHERE "shop_id" = $1 AND NOT "resolved"
+`
+
+func (q *Queries) CountOpenShopReporters(ctx context.Context, shopID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOpenShopReporters, shopID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createShopReport = `-- name: CreateShopReport :execrows
+INSERT INTO shop_report ("shop_id", "reporter_id", "reason", "detail") VALUES ($1, $2, $3, $4)
+ON CONFLICT ("shop_id", "reporter_id") WHERE NOT "resolved" DO NOTHING
+`
+
+type CreateShopReportParams struct {
+	ShopID     int64
+	ReporterID int64
+	Reason     string
+	Detail     string
+}
+
+func (q *Queries) CreateShopReport(ctx context.Context, arg CreateShopReportParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createShopReport,
+		arg.ShopID,
+		arg.ReporterID,
+		arg.Reason,
+		arg.Detail,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const flagShopForReview = `-- name: FlagShopForReview :execrows
+UPDATE "shop"
+SET "status" = 'pending_review'
+WHERE "id" = $1 AND "status" = 'active'
+`
+
+func (q *Queries) FlagShopForReview(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, flagShopForReview, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listPendingShopReportForwards = `-- name: ListPendingShopReportForwards :many
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop
+WHERE "report_forward_pending_since" < $1
+ORDER BY "report_forward_pending_since"
+LIMIT $2
+`
+
+type ListPendingShopReportForwardsParams struct {
+	PendingBefore sql.NullTime
+	RowLimit      int32
+}
+
+func (q *Queries) ListPendingShopReportForwards(ctx context.Context, arg ListPendingShopReportForwardsParams) ([]Shop, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingShopReportForwards, arg.PendingBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shop
+	for rows.Next() {
+		var i Shop
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Avatar,
+			&i.CreatedAt,
+			&i.Description,
+			&i.IsOfficial,
+			&i.Banner,
+			&i.ContactPhone,
+			&i.ContactEmail,
+			&i.Address,
+			&i.Status,
+			&i.Timezone,
+			&i.VacationStart,
+			&i.VacationEnd,
+			&i.VacationMessage,
+			&i.ReviewCount,
+			&i.ReviewRatingSum,
+			&i.ReportForwardPendingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markShopReportForwardPending = `-- name: MarkShopReportForwardPending :exec
+UPDATE "shop"
+SET "report_forward_pending_since" = now()
+WHERE "id" = $1
+`
+
+func (q *Queries) MarkShopReportForwardPending(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markShopReportForwardPending, id)
+	return err
+}
+
+const resolveShopReports = `-- name: ResolveShopReports :exec
+UPDATE shop_report
+SET "resolved" = true
+WHERE "shop_id" = $1 AND NOT "resolved"
+`
+
+func (q *Queries) ResolveShopReports(ctx context.Context, shopID int64) error {
+	_, err := q.db.ExecContext(ctx, resolveShopReports, shopID)
+	return err
+}
@@ -0,0 +1,194 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_review.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const addShopRating = `-- name: AddShopRating :exec
+UPDATE "shop"
+SET "review_count" = "review_count" + $1, "review_rating_sum" = "review_rating_sum" + $2
+WHERE "id" = $3
+`
+
+type AddShopRatingParams struct {
+	CountDelta  int64
+	RatingDelta int64
+	ID          int64
+}
+
+func (q *Queries) AddShopRating(ctx context.Context, arg AddShopRatingParams) error {
+	_, err := q.db.ExecContext(ctx, addShopRating, arg.CountDelta, arg.RatingDelta, arg.ID)
+	return err
+}
+
+const createShopReview = `-- name: CreateShopReview :one
+INSERT INTO shop_review ("shop_id", "user_id", "rating", "comment") VALUES ($1, $2, $3, $4)
+RETURNING id, shop_id, user_id, rating, comment, reply, replied_at, created_at, updated_at
+`
+
+type CreateShopReviewParams struct {
+	ShopID  int64
+	UserID  int64
+	Rating  int16
+	Comment string
+}
+
+func (q *Queries) CreateShopReview(ctx context.Context, arg CreateShopReviewParams) (ShopReview, error) {
+	row := q.db.QueryRowContext(ctx, createShopReview,
+		arg.ShopID,
+		arg.UserID,
+		arg.Rating,
+		arg.Comment,
+	)
+	var i ShopReview
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.UserID,
+		&i.Rating,
+		&i.Comment,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getShopReviewForUpdate = `-- name: GetShopReviewForUpdate :one
+SELECT id, shop_id, user_id, rating, comment, reply, replied_at, created_at, updated_at FROM shop_review WHERE "shop_id" = $1 AND "user_id" = $2 FOR UPDATE
+`
+
+type GetShopReviewForUpdateParams struct {
+	ShopID int64
+	UserID int64
+}
+
+func (q *Queries) GetShopReviewForUpdate(ctx context.Context, arg GetShopReviewForUpdateParams) (ShopReview, error) {
+	row := q.db.QueryRowContext(ctx, getShopReviewForUpdate, arg.ShopID, arg.UserID)
+	var i ShopReview
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.UserID,
+		&i.Rating,
+		&i.Comment,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listShopReviews = `-- name: ListShopReviews :many
+SELECT id, shop_id, user_id, rating, comment, reply, replied_at, created_at, updated_at FROM shop_review
+WHERE "shop_id" = $1
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListShopReviewsParams struct {
+	ShopID   int64
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListShopReviews(ctx context.Context, arg ListShopReviewsParams) ([]ShopReview, error) {
+	rows, err := q.db.QueryContext(ctx, listShopReviews, arg.ShopID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShopReview
+	for rows.Next() {
+		var i ShopReview
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.UserID,
+			&i.Rating,
+			&i.Comment,
+			&i.Reply,
+			&i.RepliedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replyShopReview = `-- name: ReplyShopReview :one
+UPDATE shop_review
+SET "reply" = $3, "replied_at" = now()
+WHERE "id" = $1 AND "shop_id" = $2
+RETURNING id, shop_id, user_id, rating, comment, reply, replied_at, created_at, updated_at
+`
+
+type ReplyShopReviewParams struct {
+	ID     int64
+	ShopID int64
+	Reply  string
+}
+
+func (q *Queries) ReplyShopReview(ctx context.Context, arg ReplyShopReviewParams) (ShopReview, error) {
+	row := q.db.QueryRowContext(ctx, replyShopReview, arg.ID, arg.ShopID, arg.Reply)
+	var i ShopReview
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.UserID,
+		&i.Rating,
+		&i.Comment,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateShopReview = `-- name: UpdateShopReview :one
+UPDATE shop_review
+SET "rating" = $2, "comment" = $3, "updated_at" = now()
+WHERE "id" = $1
+RETURNING id, shop_id, user_id, rating, comment, reply, replied_at, created_at, updated_at
+`
+
+type UpdateShopReviewParams struct {
+	ID      int64
+	Rating  int16
+	Comment string
+}
+
+func (q *Queries) UpdateShopReview(ctx context.Context, arg UpdateShopReviewParams) (ShopReview, error) {
+	row := q.db.QueryRowContext(ctx, updateShopReview, arg.ID, arg.Rating, arg.Comment)
+	var i ShopReview
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.UserID,
+		&i.Rating,
+		&i.Comment,
+		&i.Reply,
+		&i.RepliedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,258 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: shop_voucher.sql
+
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const countUserVoucherRedemptions = `-- name: CountUserVoucherRedemptions :one
+SELECT count(*) FROM voucher_redemption WHERE "voucher_id" = $1 AND "user_id" = $2
+`
+
+type CountUserVoucherRedemptionsParams struct {
+	VoucherID int64
+	UserID    int64
+}
+
+func (q *Queries) CountUserVoucherRedemptions(ctx context.Context, arg CountUserVoucherRedemptionsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserVoucherRedemptions, arg.VoucherID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createShopVoucher = `-- name: CreateShopVoucher :one
+INSERT INTO shop_voucher ("shop_id", "code", "kind", "value", "min_order_value", "usage_limit", "per_user_limit", "starts_at", "ends_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, shop_id, code, kind, value, min_order_value, usage_limit, per_user_limit, used_count, starts_at, ends_at, created_at
+`
+
+type CreateShopVoucherParams struct {
+	ShopID        int64
+	Code          string
+	Kind          string
+	Value         int64
+	MinOrderValue int64
+	UsageLimit    int32
+	PerUserLimit  int32
+	StartsAt      time.Time
+	EndsAt        time.Time
+}
+
+func (q *Queries) CreateShopVoucher(ctx context.Context, arg CreateShopVoucherParams) (ShopVoucher, error) {
+	row := q.db.QueryRowContext(ctx, createShopVoucher,
+		arg.ShopID,
+		arg.Code,
+		arg.Kind,
+		arg.Value,
+		arg.MinOrderValue,
+		arg.UsageLimit,
+		arg.PerUserLimit,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var i ShopVoucher
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Code,
+		&i.Kind,
+		&i.Value,
+		&i.MinOrderValue,
+		&i.UsageLimit,
+		&i.PerUserLimit,
+		&i.UsedCount,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createVoucherRedemption = `-- name: CreateVoucherRedemption :one
+INSERT INTO voucher_redemption ("voucher_id", "user_id", "order_id", "subtotal", "discount")
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, voucher_id, user_id, order_id, subtotal, discount, created_at
+`
+
+type CreateVoucherRedemptionParams struct {
+	VoucherID int64
+	UserID    int64
+	OrderID   int64
+	Subtotal  int64
+	Discount  int64
+}
+
+func (q *Queries) CreateVoucherRedemption(ctx context.Context, arg CreateVoucherRedemptionParams) (VoucherRedemption, error) {
+	row := q.db.QueryRowContext(ctx, createVoucherRedemption,
+		arg.VoucherID,
+		arg.UserID,
+		arg.OrderID,
+		arg.Subtotal,
+		arg.Discount,
+	)
+	var i VoucherRedemption
+	err := row.Scan(
+		&i.ID,
+		&i.VoucherID,
+		&i.UserID,
+		&i.OrderID,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getShopVoucherByCode = `-- name: GetShopVoucherByCode :one
+SELECT id, shop_id, code, kind, value, min_order_value, usage_limit, per_user_limit, used_count, starts_at, ends_at, created_at FROM shop_voucher WHERE "shop_id" = $1 AND "code" = $2
+`
+
+type GetShopVoucherByCodeParams struct {
+	ShopID int64
+	Code   string
+}
+
+func (q *Queries) GetShopVoucherByCode(ctx context.Context, arg GetShopVoucherByCodeParams) (ShopVoucher, error) {
+	row := q.db.QueryRowContext(ctx, getShopVoucherByCode, arg.ShopID, arg.Code)
+	var i ShopVoucher
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Code,
+		&i.Kind,
+		&i.Value,
+		&i.MinOrderValue,
+		&i.UsageLimit,
+		&i.PerUserLimit,
+		&i.UsedCount,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getShopVoucherByCodeForUpdate = `-- name: GetShopVoucherByCodeForUpdate :one
+SELECT id, shop_id, code, kind, value, min_order_value, usage_limit, per_user_limit, used_count, starts_at, ends_at, created_at FROM shop_voucher WHERE "shop_id" = $1 AND "code" = $2 FOR UPDATE
+`
+
+type GetShopVoucherByCodeForUpdateParams struct {
+	ShopID int64
+	Code   string
+}
+
+func (q *Queries) GetShopVoucherByCodeForUpdate(ctx context.Context, arg GetShopVoucherByCodeForUpdateParams) (ShopVoucher, error) {
+	row := q.db.QueryRowContext(ctx, getShopVoucherByCodeForUpdate, arg.ShopID, arg.Code)
+	var i ShopVoucher
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Code,
+		&i.Kind,
+		&i.Value,
+		&i.MinOrderValue,
+		&i.UsageLimit,
+		&i.PerUserLimit,
+		&i.UsedCount,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getVoucherRedemptionByOrder = `-- name: GetVoucherRedemptionByOrder :one
+SELECT id, voucher_id, user_id, order_id, subtotal, discount, created_at FROM voucher_redemption WHERE "voucher_id" = $1 AND "order_id" = $2
+`
+
+type GetVoucherRedemptionByOrderParams struct {
+	VoucherID int64
+	OrderID   int64
+}
+
+func (q *Queries) GetVoucherRedemptionByOrder(ctx context.Context, arg GetVoucherRedemptionByOrderParams) (VoucherRedemption, error) {
+	row := q.db.QueryRowContext(ctx, getVoucherRedemptionByOrder, arg.VoucherID, arg.OrderID)
+	var i VoucherRedemption
+	err := row.Scan(
+		&i.ID,
+		&i.VoucherID,
+		&i.UserID,
+		&i.OrderID,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listActiveShopVouchers = `-- name: ListActiveShopVouchers :many
+SELECT id, shop_id, code, kind, value, min_order_value, usage_limit, per_user_limit, used_count, starts_at, ends_at, created_at FROM shop_voucher
+WHERE "shop_id" = $1
+    AND "starts_at" <= now() AND "ends_at" > now()
+    AND ("usage_limit" = 0 OR "used_count" < "usage_limit")
+    AND ($2::int8 = 0 OR "id" < $2::int8)
+ORDER BY "id" DESC
+LIMIT $3
+`
+
+type ListActiveShopVouchersParams struct {
+	ShopID   int64
+	Cursor   int64
+	RowLimit int32
+}
+
+func (q *Queries) ListActiveShopVouchers(ctx context.Context, arg ListActiveShopVouchersParams) ([]ShopVoucher, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveShopVouchers, arg.ShopID, arg.Cursor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShopVoucher
+	for rows.Next() {
+		var i ShopVoucher
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.Code,
+			&i.Kind,
+			&i.Value,
+			&i.MinOrderValue,
+			&i.UsageLimit,
+			&i.PerUserLimit,
+			&i.UsedCount,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const useShopVoucher = `-- name: UseShopVoucher :execrows
+UPDATE shop_voucher
+SET "used_count" = "used_count" + 1
+WHERE "id" = $1 AND ("usage_limit" = 0 OR "used_count" < "usage_limit")
+`
+
+func (q *Queries) UseShopVoucher(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useShopVoucher, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
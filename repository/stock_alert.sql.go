@@ -0,0 +1,250 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.0
+// source: stock_alert.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const createStockAlert = `-- name: CreateStockAlert :execrows
+INSERT INTO stock_alert ("shop_id", "product_id", "product_name", "inventory", "threshold")
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("product_id") WHERE NOT "resolved" DO NOTHING
+`
+
+type CreateStockAlertParams struct {
+	ShopID      int64
+	ProductID   int64
+	ProductName string
+	Inventory   int64
+	Threshold   int32
+}
+
+func (q *Queries) CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createStockAlert,
+		arg.ShopID,
+		arg.ProductID,
+		arg.ProductName,
+		arg.Inventory,
+		arg.Threshold,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listOpenStockAlertsAfter = `-- name: ListOpenStockAlertsAfter :many
+SELECT id, shop_id, product_id, product_name, inventory, threshold, resolved, created_at, resolved_at FROM stock_alert
+WHERE "shop_id" = $1 AND NOT "resolved" AND "id" > $2
+ORDER BY "id"
+LIMIT $3
+`
+
+type ListOpenStockAlertsAfterParams struct {
+	ShopID   int64
+	AfterID  int64
+	RowLimit int32
+}
+
+func (q *Queries) ListOpenStockAlertsAfter(ctx context.Context, arg ListOpenStockAlertsAfterParams) ([]StockAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listOpenStockAlertsAfter, arg.ShopID, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockAlert
+	for rows.Next() {
+		var i StockAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.Inventory,
+			&i.Threshold,
+			&i.Resolved,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockAlerts = `-- name: ListStockAlerts :many
+SELECT id, shop_id, product_id, product_name, inventory, threshold, resolved, created_at, resolved_at FROM stock_alert
+WHERE "shop_id" = $1
+    AND ($2::boolean OR NOT "resolved")
+    AND ($3::int8 = 0 OR "id" < $3::int8)
+ORDER BY "id" DESC
+LIMIT $4
+`
+
+type ListStockAlertsParams struct {
+	ShopID          int64
+	IncludeResolved bool
+	Cursor          int64
+	RowLimit        int32
+}
+
+func (q *Queries) ListStockAlerts(ctx context.Context, arg ListStockAlertsParams) ([]StockAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listStockAlerts,
+		arg.ShopID,
+		arg.IncludeResolved,
+		arg.Cursor,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockAlert
+	for rows.Next() {
+		var i StockAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShopID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.Inventory,
+			&i.Threshold,
+			&i.Resolved,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockThresholds = `-- name: ListStockThresholds :many
+SELECT shop_id, product_id, threshold FROM stock_threshold
+WHERE "shop_id" = $1
+ORDER BY "product_id"
+`
+
+func (q *Queries) ListStockThresholds(ctx context.Context, shopID int64) ([]StockThreshold, error) {
+	rows, err := q.db.QueryContext(ctx, listStockThresholds, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockThreshold
+	for rows.Next() {
+		var i StockThreshold
+		if err := rows.Scan(&i.ShopID, &i.ProductID, &i.Threshold); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockWatchedShops = `-- name: ListStockWatchedShops :many
+SELECT id, seller_id, name, avatar, created_at, description, is_official, banner, contact_phone, contact_email, address, status, timezone, vacation_start, vacation_end, vacation_message, review_count, review_rating_sum, report_forward_pending_since FROM shop
+WHERE "status" NOT IN ('suspended', 'closed')
+    AND EXISTS (
+        SELECT 1 FROM stock_threshold
+        WHERE stock_threshold."shop_id" = shop."id" AND stock_threshold."threshold" > 0
+    )
+ORDER BY "id"
+`
+
+func (q *Queries) ListStockWatchedShops(ctx context.Context) ([]Shop, error) {
+	rows, err := q.db.QueryContext(ctx, listStockWatchedShops)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shop
+	for rows.Next() {
+		var i Shop
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Avatar,
+			&i.CreatedAt,
+			&i.Description,
+			&i.IsOfficial,
+			&i.Banner,
+			&i.ContactPhone,
+			&i.ContactEmail,
+			&i.Address,
+			&i.Status,
+			&i.Timezone,
+			&i.VacationStart,
+			&i.VacationEnd,
+			&i.VacationMessage,
+			&i.ReviewCount,
+			&i.ReviewRatingSum,
+			&i.ReportForwardPendingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resolveStockAlert = `-- name: ResolveStockAlert :exec
+UPDATE stock_alert
+SET "resolved" = true, "resolved_at" = now()
+WHERE "product_id" = $1 AND NOT "resolved"
+`
+
+func (q *Queries) ResolveStockAlert(ctx context.Context, productID int64) error {
+	_, err := q.db.ExecContext(ctx, resolveStockAlert, productID)
+	return err
+}
+
+const setStockThreshold = `-- name: SetStockThreshold :one
+INSERT INTO stock_threshold ("shop_id", "product_id", "threshold") VALUES ($1, $2, $3)
+ON CONFLICT ("shop_id", "product_id") DO UPDATE SET "threshold" = EXCLUDED."threshold"
+RETURNING shop_id, product_id, threshold
+`
+
+type SetStockThresholdParams struct {
+	ShopID    int64
+	ProductID int64
+	Threshold int32
+}
+
+func (q *Queries) SetStockThreshold(ctx context.Context, arg SetStockThresholdParams) (StockThreshold, error) {
+	row := q.db.QueryRowContext(ctx, setStockThreshold, arg.ShopID, arg.ProductID, arg.Threshold)
+	var i StockThreshold
+	err := row.Scan(&i.ShopID, &i.ProductID, &i.Threshold)
+	return i, err
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Store provides all queries plus transaction support
+type Store struct {
+	*Queries
+	db *sql.DB
+}
+
+// NewStore ...
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+// ExecTx runs fn inside a database transaction, rolling back if fn fails
+func (store *Store) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(store.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EnsureOfficialShop makes the shop of sellerID the official store. A seller
+// that already owns a shop has it promoted, taking the official flag from the
+// previous official shop, otherwise the official shop is handed over to the
+// seller or created. The name is only applied when the official flag moves,
+// an empty name keeps the current one so the seller's renames survive boots.
+func (store *Store) EnsureOfficialShop(ctx context.Context, sellerID int64, name string) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		shop, err := q.GetShopBySellerID(ctx, sellerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			if name == "" {
+				official, err := q.GetOfficialShop(ctx)
+				if errors.Is(err, sql.ErrNoRows) {
+					return errors.New("official shop name is required to create the official shop")
+				}
+				if err != nil {
+					return err
+				}
+				name = official.Name
+			}
+			return q.UpsertOfficialShop(ctx, UpsertOfficialShopParams{
+				SellerID: sellerID,
+				Name:     name,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		if shop.IsOfficial {
+			return nil
+		}
+
+		if err := q.DemoteOfficialShop(ctx, sellerID); err != nil {
+			return err
+		}
+		if name == "" {
+			name = shop.Name
+		}
+		return q.PromoteOfficialShop(ctx, PromoteOfficialShopParams{
+			SellerID: sellerID,
+			Name:     name,
+		})
+	})
+}
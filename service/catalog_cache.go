@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+)
+
+// catalogs kept at most, public pages of the busiest shops stay cached
+const maxCatalogCacheEntries = 256
+
+// catalogKey identifies a catalog of a supplier in one sort order
+type catalogKey struct {
+	supplierID  int64
+	byTime      bool
+	byPriceInc  bool
+	byPriceDesc bool
+}
+
+type catalogEntry struct {
+	products  []*pb.Product
+	fetchedAt time.Time
+}
+
+// catalogCache keeps the catalogs served on public pages for a while, so
+// visitors can't make every request page through product-service. Cached
+// slices are shared and must not be modified.
+type catalogCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[catalogKey]catalogEntry
+}
+
+func newCatalogCache(ttl time.Duration) *catalogCache {
+	return &catalogCache{
+		ttl:     ttl,
+		entries: make(map[catalogKey]catalogEntry),
+	}
+}
+
+func (cache *catalogCache) get(key catalogKey) ([]*pb.Product, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.fetchedAt) > cache.ttl {
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	return entry.products, true
+}
+
+func (cache *catalogCache) put(key catalogKey, products []*pb.Product) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if len(cache.entries) >= maxCatalogCacheEntries {
+		for k, entry := range cache.entries {
+			if time.Since(entry.fetchedAt) > cache.ttl {
+				delete(cache.entries, k)
+			}
+		}
+	}
+	// still full of fresh catalogs, make room for this one
+	for k := range cache.entries {
+		if len(cache.entries) < maxCatalogCacheEntries {
+			break
+		}
+		delete(cache.entries, k)
+	}
+
+	cache.entries[key] = catalogEntry{
+		products:  products,
+		fetchedAt: time.Now(),
+	}
+}
+
+// cachedSupplierProducts is listSupplierProducts served from the catalog
+// cache, the result may be up to Config.CatalogCacheTTL old and must not be
+// modified
+func (srv *ShopService) cachedSupplierProducts(ctx context.Context, query *pb.GetProductBySupplierRequest) ([]*pb.Product, error) {
+	key := catalogKey{
+		supplierID:  query.GetSupplierId(),
+		byTime:      query.GetByTime(),
+		byPriceInc:  query.GetByPriceInc(),
+		byPriceDesc: query.GetByPriceDesc(),
+	}
+	if products, ok := srv.catalog.get(key); ok {
+		return products, nil
+	}
+
+	products, err := srv.listSupplierProducts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	srv.catalog.put(key, products)
+
+	return products, nil
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// flash sale status values, stored by name in flash_sale.status
+var (
+	saleRunning = pb.FlashSaleStatus_sale_running.String()
+	saleEnded   = pb.FlashSaleStatus_sale_ended.String()
+	saleFailed  = pb.FlashSaleStatus_sale_failed.String()
+)
+
+const (
+	maxFlashSaleDuration = 7 * 24 * time.Hour
+	flashSaleBatch       = 50
+	// sales starting or ending for this long were interrupted by a crash
+	flashSaleStaleAfter = 2 * time.Minute
+)
+
+// CreateFlashSale schedules a sale of quantity units of a product of the
+// caller at sale_price
+func (srv *ShopService) CreateFlashSale(ctx context.Context, req *pb.CreateFlashSaleRequest) (*pb.FlashSale, error) {
+	if err := validateFlashSale(req); err != nil {
+		return nil, err
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+	product, err := srv.ownProduct(ctx, me.ID, req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSalePrice() >= product.GetPrice() {
+		return nil, status.Error(codes.InvalidArgument, "Giá flash sale phải thấp hơn giá hiện tại")
+	}
+
+	sale, err := srv.shopStore.CreateFlashSale(ctx, repository.CreateFlashSaleParams{
+		ShopID:    shop.ID,
+		SellerID:  me.ID,
+		ProductID: req.GetProductId(),
+		SalePrice: req.GetSalePrice(),
+		Quantity:  req.GetQuantity(),
+		StartsAt:  req.GetStartsAt().AsTime(),
+		EndsAt:    req.GetEndsAt().AsTime(),
+	})
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "Sản phẩm đã có chương trình flash sale")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't create flash sale: %v", err)
+	}
+
+	return flashSale(sale), nil
+}
+
+// ListActiveFlashSales returns the running sales, newest first
+func (srv *ShopService) ListActiveFlashSales(ctx context.Context, req *pb.ListActiveFlashSalesRequest) (*pb.ListActiveFlashSalesResponse, error) {
+	limit := pageLimit(req.GetLimit())
+	sales, err := srv.shopStore.ListRunningFlashSales(ctx, repository.ListRunningFlashSalesParams{
+		ShopID:   req.GetShopId(),
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list flash sales: %v", err)
+	}
+
+	resp := &pb.ListActiveFlashSalesResponse{
+		FlashSales: make([]*pb.FlashSale, 0, len(sales)),
+	}
+	for _, sale := range sales {
+		resp.FlashSales = append(resp.FlashSales, flashSale(sale))
+	}
+	if len(sales) == int(limit) {
+		resp.NextCursor = sales[len(sales)-1].ID
+	}
+
+	return resp, nil
+}
+
+// GetEffectivePrice returns the price a product sells at right now
+func (srv *ShopService) GetEffectivePrice(ctx context.Context, req *pb.GetEffectivePriceRequest) (*pb.EffectivePrice, error) {
+	sale, err := srv.shopStore.GetRunningFlashSaleByProduct(ctx, req.GetProductId())
+	if err == nil {
+		return &pb.EffectivePrice{
+			ProductId:     sale.ProductID,
+			Price:         sale.SalePrice,
+			OriginalPrice: sale.OriginalPrice,
+			FlashSale:     flashSale(sale),
+		}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "can't get flash sale: %v", err)
+	}
+
+	product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		ProductId: req.GetProductId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EffectivePrice{
+		ProductId:     product.GetProductId(),
+		Price:         product.GetPrice(),
+		OriginalPrice: product.GetPrice(),
+	}, nil
+}
+
+// RunFlashSales starts and ends flash sales on an interval, until ctx is
+// done
+func (srv *ShopService) RunFlashSales(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		srv.runFlashSales(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *ShopService) runFlashSales(ctx context.Context) {
+	// sales that ended while the scheduler was down never touched the product
+	_, err := srv.shopStore.FailMissedFlashSales(ctx, "the sale ended before it could start")
+	if err != nil {
+		log.Println("can't fail missed flash sales: ", err)
+	}
+
+	// end first, so a product whose sale just ended can start the next one
+	ending, err := srv.shopStore.ClaimFlashSalesToEnd(ctx, repository.ClaimFlashSalesToEndParams{
+		StaleBefore: time.Now().Add(-flashSaleStaleAfter),
+		RowLimit:    flashSaleBatch,
+	})
+	if err != nil {
+		log.Println("can't claim flash sales to end: ", err)
+	}
+	for _, sale := range ending {
+		if err := srv.endFlashSale(ctx, sale, saleEnded, sale.LastError); err != nil {
+			log.Printf("end flash sale %d: %v", sale.ID, err)
+		}
+	}
+
+	starting, err := srv.shopStore.ClaimFlashSalesToStart(ctx, flashSaleBatch)
+	if err != nil {
+		log.Println("can't claim flash sales to start: ", err)
+		return
+	}
+	for _, sale := range starting {
+		if err := srv.startFlashSale(ctx, sale); err != nil {
+			log.Printf("start flash sale %d: %v", sale.ID, err)
+		}
+	}
+}
+
+// startFlashSale lowers the price of the product and withholds the stock
+// beyond the sale quantity, so only that many units sell at the sale price.
+// Every change is recorded on the sale before it is made, so it can be
+// undone.
+//
+// UpdateProduct replaces the whole product, stock included: an order placed
+// between reading the product and writing the sale price back gets its units
+// back. The product is read again right before the write to keep that window
+// short, product-service has no partial update to close it.
+func (srv *ShopService) startFlashSale(ctx context.Context, sale repository.FlashSale) error {
+	product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		ProductId: sale.ProductID,
+	})
+	if err == nil && product.GetSupplierId() != sale.SellerID {
+		err = status.Error(codes.PermissionDenied, "product changed owner")
+	}
+	if err == nil {
+		err = srv.checkShopActive(ctx, sale.SellerID)
+	}
+	if err == nil && sale.SalePrice >= product.GetPrice() {
+		err = status.Error(codes.FailedPrecondition, "sale price is no longer below the product price")
+	}
+	if err != nil {
+		return srv.endFlashSale(ctx, sale, saleFailed, status.Convert(err).Message())
+	}
+
+	// record the price before changing it, restoring a price that was never
+	// changed is harmless
+	err = srv.shopStore.RecordFlashSalePrice(ctx, repository.RecordFlashSalePriceParams{
+		ID:            sale.ID,
+		PriceApplied:  true,
+		OriginalPrice: product.GetPrice(),
+	})
+	if err != nil {
+		return err
+	}
+	sale.PriceApplied = true
+	sale.OriginalPrice = product.GetPrice()
+
+	err = retryTransient(ctx, func() error {
+		product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+			ProductId: sale.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+		if product.GetPrice() != sale.OriginalPrice {
+			return status.Error(codes.FailedPrecondition, "product price changed while the sale started")
+		}
+
+		update := productUpdateParams(sale.SellerID, product)
+		update.Price = sale.SalePrice
+		_, err = srv.productClient.UpdateProduct(ctx, update)
+		return err
+	})
+	if err != nil {
+		return srv.endFlashSale(ctx, sale, saleFailed, status.Convert(err).Message())
+	}
+
+	product, err = srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		ProductId: sale.ProductID,
+	})
+	if err != nil {
+		return srv.endFlashSale(ctx, sale, saleFailed, status.Convert(err).Message())
+	}
+	withholding := int64(product.GetInventory()) - sale.Quantity
+	if withholding > 0 {
+		// recorded first, endFlashSale works out whether the stock was
+		// withheld when the call below fails or is interrupted
+		err = srv.shopStore.RecordFlashSaleWithholding(ctx, repository.RecordFlashSaleWithholdingParams{
+			ID:                sale.ID,
+			Withholding:       withholding,
+			WithholdInventory: int64(product.GetInventory()),
+			WithholdSold:      product.GetTotalSold(),
+		})
+		if err != nil {
+			return err
+		}
+		sale.Withholding = withholding
+		sale.WithholdInventory = int64(product.GetInventory())
+		sale.WithholdSold = product.GetTotalSold()
+
+		// not retried, a lost response could withhold the stock twice
+		_, err = srv.productClient.DescInventory(ctx, &pb.DescInventoryRequest{
+			ProductId: sale.ProductID,
+			Count:     int32(withholding),
+		})
+		if err != nil {
+			return srv.endFlashSale(ctx, sale, saleFailed, status.Convert(err).Message())
+		}
+
+		err = srv.shopStore.RecordFlashSaleWithheld(ctx, repository.RecordFlashSaleWithheldParams{
+			ID:       sale.ID,
+			Withheld: withholding,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return srv.shopStore.FinishFlashSale(ctx, repository.FinishFlashSaleParams{
+		ID:     sale.ID,
+		Status: saleRunning,
+	})
+}
+
+// endFlashSale undoes the changes a sale made to its product and moves it to
+// status to. When a step fails the sale is left for the next run to retry.
+func (srv *ShopService) endFlashSale(ctx context.Context, sale repository.FlashSale, to string, lastError string) error {
+	if sale.Withholding > 0 {
+		withheld, err := srv.reconcileWithholding(ctx, sale)
+		if err != nil {
+			return err
+		}
+
+		err = srv.shopStore.RecordFlashSaleWithheld(ctx, repository.RecordFlashSaleWithheldParams{
+			ID:       sale.ID,
+			Withheld: withheld,
+		})
+		if err != nil {
+			return err
+		}
+		sale.Withholding = 0
+		sale.Withheld = withheld
+	}
+
+	if sale.PriceApplied {
+		err := retryTransient(ctx, func() error {
+			product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+				ProductId: sale.ProductID,
+			})
+			if err != nil {
+				return err
+			}
+			// the seller repriced the product during the sale, keep their
+			// price; a price never lowered is left alone too
+			if product.GetPrice() != sale.SalePrice {
+				return nil
+			}
+
+			update := productUpdateParams(sale.SellerID, product)
+			update.Price = sale.OriginalPrice
+			_, err = srv.productClient.UpdateProduct(ctx, update)
+			return err
+		})
+		// nothing to restore on a deleted product
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		err = srv.shopStore.RecordFlashSalePrice(ctx, repository.RecordFlashSalePriceParams{
+			ID:            sale.ID,
+			PriceApplied:  false,
+			OriginalPrice: sale.OriginalPrice,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if sale.Withheld > 0 {
+		err := retryTransient(ctx, func() error {
+			_, err := srv.productClient.IncInventory(ctx, &pb.IncInventoryRequest{
+				ProductId: sale.ProductID,
+				Count:     int32(sale.Withheld),
+			})
+			return err
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		err = srv.shopStore.RecordFlashSaleWithheld(ctx, repository.RecordFlashSaleWithheldParams{
+			ID:       sale.ID,
+			Withheld: 0,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return srv.shopStore.FinishFlashSale(ctx, repository.FinishFlashSaleParams{
+		ID:        sale.ID,
+		Status:    to,
+		LastError: lastError,
+	})
+}
+
+// reconcileWithholding works out whether the stock recorded as withholding
+// was withheld, returning the units withheld. Without it the product would
+// hold the recorded inventory less the units sold since; with it, that much
+// less again. The stock is taken as withheld when it is closer to the latter.
+func (srv *ShopService) reconcileWithholding(ctx context.Context, sale repository.FlashSale) (int64, error) {
+	product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		ProductId: sale.ProductID,
+	})
+	// nothing to give back to a deleted product
+	if status.Code(err) == codes.NotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	sold := product.GetTotalSold() - sale.WithholdSold
+	notWithheld := sale.WithholdInventory - sold
+	if 2*(notWithheld-int64(product.GetInventory())) >= sale.Withholding {
+		return sale.Withholding, nil
+	}
+	return 0, nil
+}
+
+func validateFlashSale(req *pb.CreateFlashSaleRequest) error {
+	if req.GetSalePrice() <= 0 {
+		return status.Error(codes.InvalidArgument, "Giá flash sale phải lớn hơn 0")
+	}
+	if req.GetQuantity() <= 0 {
+		return status.Error(codes.InvalidArgument, "Số lượng flash sale phải lớn hơn 0")
+	}
+	if req.GetStartsAt() == nil || req.GetEndsAt() == nil {
+		return status.Error(codes.InvalidArgument, "starts_at and ends_at are required")
+	}
+
+	startsAt, endsAt := req.GetStartsAt().AsTime(), req.GetEndsAt().AsTime()
+	if !endsAt.After(startsAt) {
+		return status.Error(codes.InvalidArgument, "Thời gian kết thúc phải sau thời gian bắt đầu")
+	}
+	if !endsAt.After(time.Now()) {
+		return status.Error(codes.InvalidArgument, "Thời gian kết thúc phải ở trong tương lai")
+	}
+	if endsAt.Sub(startsAt) > maxFlashSaleDuration {
+		return status.Errorf(codes.InvalidArgument, "Flash sale kéo dài tối đa %d ngày", maxFlashSaleDuration/(24*time.Hour))
+	}
+
+	return nil
+}
+
+func flashSale(sale repository.FlashSale) *pb.FlashSale {
+	return &pb.FlashSale{
+		Id:            sale.ID,
+		ShopId:        sale.ShopID,
+		ProductId:     sale.ProductID,
+		SalePrice:     sale.SalePrice,
+		Quantity:      sale.Quantity,
+		StartsAt:      timestamppb.New(sale.StartsAt),
+		EndsAt:        timestamppb.New(sale.EndsAt),
+		Status:        pb.FlashSaleStatus(pb.FlashSaleStatus_value[sale.Status]),
+		OriginalPrice: sale.OriginalPrice,
+		LastError:     sale.LastError,
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxAdjustmentReasonLength = 256
+	restockReason             = "restock"
+)
+
+// GetInventory returns the stock of a product of the caller
+func (srv *ShopService) GetInventory(ctx context.Context, req *pb.ProductInventoryRequest) (*pb.ProductInventory, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := srv.ownProduct(ctx, me.ID, req.GetProductId()); err != nil {
+		return nil, err
+	}
+
+	return srv.productInventory(ctx, req.GetProductId())
+}
+
+// RestockProduct adds quantity items to the stock of a product
+func (srv *ShopService) RestockProduct(ctx context.Context, req *pb.RestockProductRequest) (*pb.ProductInventory, error) {
+	if req.GetQuantity() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Số lượng nhập kho phải lớn hơn 0")
+	}
+
+	reason := req.GetReason()
+	if strings.TrimSpace(reason) == "" {
+		reason = restockReason
+	}
+
+	return srv.adjustInventory(ctx, req.GetProductId(), req.GetQuantity(), reason)
+}
+
+// AdjustInventory corrects the stock of a product, e.g. after a stocktake or
+// for damaged items
+func (srv *ShopService) AdjustInventory(ctx context.Context, req *pb.AdjustInventoryRequest) (*pb.ProductInventory, error) {
+	if req.GetDelta() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "delta can't be 0")
+	}
+
+	return srv.adjustInventory(ctx, req.GetProductId(), req.GetDelta(), req.GetReason())
+}
+
+// adjustInventory changes the stock of a product of the caller by delta and
+// records the change
+func (srv *ShopService) adjustInventory(ctx context.Context, productID int64, delta int32, reason string) (*pb.ProductInventory, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "Vui lòng nhập lý do")
+	}
+	if utf8.RuneCountInString(reason) > maxAdjustmentReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Lý do không được dài quá %d kí tự", maxAdjustmentReasonLength)
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	if _, err := srv.ownProduct(ctx, me.ID, productID); err != nil {
+		return nil, err
+	}
+
+	if delta < 0 {
+		inventory, err := srv.productInventory(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		if inventory.GetCount()+int64(delta) < 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Số lượng tồn kho không đủ, hiện còn %d", inventory.GetCount())
+		}
+	}
+
+	if err := srv.shiftInventory(ctx, me.ID, productID, delta, reason); err != nil {
+		return nil, err
+	}
+
+	return srv.productInventory(ctx, productID)
+}
+
+// shiftInventory changes the stock of a product by delta on behalf of
+// actorID and records the change, the record is only committed once
+// product-service has applied the change
+func (srv *ShopService) shiftInventory(ctx context.Context, actorID int64, productID int64, delta int32, reason string) error {
+	return srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		err := q.CreateInventoryAdjustment(ctx, repository.CreateInventoryAdjustmentParams{
+			ProductID: productID,
+			ActorID:   actorID,
+			Delta:     delta,
+			Reason:    reason,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't record adjustment: %v", err)
+		}
+
+		if delta > 0 {
+			_, err = srv.productClient.IncInventory(ctx, &pb.IncInventoryRequest{
+				ProductId: productID,
+				Count:     delta,
+			})
+		} else {
+			_, err = srv.productClient.DescInventory(ctx, &pb.DescInventoryRequest{
+				ProductId: productID,
+				Count:     -delta,
+			})
+		}
+		return err
+	})
+}
+
+// ListInventoryAdjustments returns the stock changes of a product of the
+// caller, newest first
+func (srv *ShopService) ListInventoryAdjustments(ctx context.Context, req *pb.ListInventoryAdjustmentsRequest) (*pb.ListInventoryAdjustmentsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := srv.ownProduct(ctx, me.ID, req.GetProductId()); err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(req.GetLimit())
+	rows, err := srv.shopStore.ListInventoryAdjustments(ctx, repository.ListInventoryAdjustmentsParams{
+		ProductID: req.GetProductId(),
+		Cursor:    req.GetCursor(),
+		RowLimit:  limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list adjustments: %v", err)
+	}
+
+	resp := &pb.ListInventoryAdjustmentsResponse{
+		Adjustments: make([]*pb.InventoryAdjustment, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Adjustments = append(resp.Adjustments, &pb.InventoryAdjustment{
+			Id:        row.ID,
+			ProductId: row.ProductID,
+			ActorId:   row.ActorID,
+			Delta:     row.Delta,
+			Reason:    row.Reason,
+			CreatedAt: timestamppb.New(row.CreatedAt),
+		})
+	}
+	if len(rows) == int(limit) {
+		resp.NextCursor = rows[len(rows)-1].ID
+	}
+
+	return resp, nil
+}
+
+func (srv *ShopService) productInventory(ctx context.Context, productID int64) (*pb.ProductInventory, error) {
+	resp, err := srv.productClient.GetListProductInventory(ctx, &pb.GetInventoryRequest{
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductInventory{
+		ProductId: productID,
+		Count:     resp.GetCount(),
+	}, nil
+}
+
+// ownProduct returns a product, checking that it is sold by supplierID
+func (srv *ShopService) ownProduct(ctx context.Context, supplierID int64, productID int64) (*pb.Product, error) {
+	product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if product.GetSupplierId() != supplierID {
+		return nil, status.Error(codes.PermissionDenied, "Sản phẩm không thuộc cửa hàng của bạn")
+	}
+
+	return product, nil
+}
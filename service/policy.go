@@ -0,0 +1,97 @@
+package service
+
+import (
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+)
+
+var (
+	public        = auth.Policy{Public: true}
+	authenticated = auth.Policy{}
+	seller        = auth.Policy{Roles: []pb.UserRole{pb.UserRole_supplier, pb.UserRole_admin}}
+	admin         = auth.Policy{Roles: []pb.UserRole{pb.UserRole_admin}}
+	internal      = auth.Policy{Internal: true}
+)
+
+func method(name string) string {
+	return "/" + pb.ShopService_ServiceDesc.ServiceName + "/" + name
+}
+
+// Policies declares who may call each ShopService method, methods missing
+// here are rejected by the auth interceptor
+var Policies = map[string]auth.Policy{
+	method("Ping"):                 public,
+	method("GetShop"):              public,
+	method("ListFollowers"):        public,
+	method("ListShopsByCategory"):  public,
+	method("ListShopProducts"):     public,
+	method("ListShopCollections"):  public,
+	method("GetShopCollection"):    public,
+	method("ListShopVouchers"):     public,
+	method("ListActiveFlashSales"): public,
+	method("GetEffectivePrice"):    public,
+	method("GetShopAvailability"):  public,
+	method("ListShopReviews"):      public,
+
+	method("RegisterShop"):      authenticated,
+	method("FollowShop"):        authenticated,
+	method("UnfollowShop"):      authenticated,
+	method("ListFollowedShops"): authenticated,
+	method("ReportShop"):        authenticated,
+	method("ValidateVoucher"):   authenticated,
+	method("RateShop"):          authenticated,
+
+	// called by the checkout of order-service with the buyer's token and
+	// the service token, see RedeemVoucher
+	method("RedeemVoucher"): internal,
+
+	// shop-scoped mutations
+	method("UpdateShopName"):      seller,
+	method("UpdateShopProfile"):   seller,
+	method("UpdateShopAvatar"):    seller,
+	method("AddProduct"):          seller,
+	method("UpdateProduct"):       seller,
+	method("DeleteProduct"):       seller,
+	method("ListMyProducts"):      seller,
+	method("ImportProducts"):      seller,
+	method("ExportProducts"):      seller,
+	method("BatchUpdateProducts"): seller,
+	method("GetShopStats"):        seller,
+	method("ReplyShopReview"):     seller,
+
+	// drafts
+	method("SaveDraft"):     seller,
+	method("ListDrafts"):    seller,
+	method("PublishDraft"):  seller,
+	method("ScheduleDraft"): seller,
+
+	// collections
+	method("CreateCollection"):   seller,
+	method("UpdateCollection"):   seller,
+	method("DeleteCollection"):   seller,
+	method("SetCollectionItems"): seller,
+
+	// promotions
+	method("CreateVoucher"):   seller,
+	method("CreateFlashSale"): seller,
+
+	// availability
+	method("SetOpeningHours"): seller,
+	method("SetVacation"):     seller,
+
+	// inventory
+	method("GetInventory"):             seller,
+	method("RestockProduct"):           seller,
+	method("AdjustInventory"):          seller,
+	method("ListInventoryAdjustments"): seller,
+	method("SetStockThreshold"):        seller,
+	method("ListStockThresholds"):      seller,
+	method("ListStockAlerts"):          seller,
+	method("WatchStockAlerts"):         seller,
+
+	// moderation
+	method("SuspendShop"):        admin,
+	method("ReinstateShop"):      admin,
+	method("CloseShop"):          admin,
+	method("ListShopsForReview"): admin,
+}
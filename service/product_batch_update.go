@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// product fields accepted in an update mask
+const (
+	productName      = "name"
+	productPrice     = "price"
+	productThumbnail = "thumbnail"
+	productInventory = "inventory"
+	productBrand     = "brand"
+)
+
+const maxBatchUpdates = 100
+
+var errBatchAborted = errors.New("skipped, an earlier update failed")
+
+// BatchUpdateProducts applies partial updates to products of the caller.
+// product-service only replaces whole products, so every update reads the
+// product right before writing it back with the masked fields changed. A
+// requested stock count moves the inventory by its difference to the count
+// read, but the write-back of the other fields still carries the stock read:
+// an order placed between the read and the write gets its units back, which
+// can oversell. product-service has no partial update to close that window,
+// so it is only kept short, and inventory-only updates skip the write-back.
+func (srv *ShopService) BatchUpdateProducts(ctx context.Context, req *pb.BatchUpdateProductsRequest) (*pb.BatchUpdateProductsResponse, error) {
+	updates := req.GetUpdates()
+	if len(updates) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "updates is required")
+	}
+	if len(updates) > maxBatchUpdates {
+		return nil, status.Errorf(codes.InvalidArgument, "Mỗi lần chỉ được cập nhật tối đa %d sản phẩm", maxBatchUpdates)
+	}
+	// restoring a product updated twice would need the value before the
+	// first update, keep it simple by rejecting duplicates
+	seen := make(map[int64]bool, len(updates))
+	for _, update := range updates {
+		if seen[update.GetProductId()] {
+			return nil, status.Errorf(codes.InvalidArgument, "product %d is updated more than once", update.GetProductId())
+		}
+		seen[update.GetProductId()] = true
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.BatchUpdateProductsResponse{
+		Results: make([]*pb.ProductUpdateResult, len(updates)),
+	}
+	// the updates applied so far, to undo them
+	var applied []productChange
+	var failed bool
+	for i, update := range updates {
+		if failed && req.GetAllOrNothing() {
+			resp.Results[i] = productUpdateResult(update.GetProductId(), errBatchAborted)
+			continue
+		}
+
+		change, err := srv.updateProduct(ctx, me.ID, update)
+		if err != nil {
+			failed = true
+		} else {
+			applied = append(applied, change)
+		}
+		resp.Results[i] = productUpdateResult(update.GetProductId(), err)
+	}
+
+	if failed && req.GetAllOrNothing() {
+		resp.RolledBack = true
+		for _, change := range applied {
+			err := srv.restoreProduct(ctx, me.ID, change)
+			for _, result := range resp.Results {
+				if result.GetProductId() != change.before.GetProductId() {
+					continue
+				}
+				result.Ok = false
+				if err != nil {
+					resp.RolledBack = false
+					result.Error = fmt.Sprintf("rollback failed: %s", status.Convert(err).Message())
+				} else {
+					result.Error = "rolled back"
+				}
+			}
+		}
+	}
+
+	for _, result := range resp.Results {
+		if result.GetOk() {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	return resp, nil
+}
+
+// productChange is an update applied to a product, with what it takes to
+// undo it
+type productChange struct {
+	before *pb.Product
+	// masked fields other than the inventory
+	fields []string
+	// units added to the stock
+	inventoryDelta int32
+}
+
+// updateProduct applies update to a product of supplierID
+func (srv *ShopService) updateProduct(ctx context.Context, supplierID int64, update *pb.ProductUpdate) (productChange, error) {
+	paths := update.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return productChange{}, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+	if err := validateProductPatch(update.GetProduct(), paths); err != nil {
+		return productChange{}, err
+	}
+
+	product, err := srv.ownProduct(ctx, supplierID, update.GetProductId())
+	if err != nil {
+		return productChange{}, err
+	}
+
+	change := productChange{before: product}
+	arg := productUpdateParams(supplierID, product)
+	patch := update.GetProduct()
+	for _, path := range paths {
+		if path == productInventory {
+			delta := patch.GetInventory() - int64(product.GetInventory())
+			if delta > math.MaxInt32 || delta < math.MinInt32 {
+				return productChange{}, status.Error(codes.InvalidArgument, "Số lượng tồn kho thay đổi quá lớn")
+			}
+			change.inventoryDelta = int32(delta)
+			continue
+		}
+
+		setProductField(arg, path, patch.GetName(), patch.GetPrice(), patch.GetThumbnail(), patch.GetBrand())
+		change.fields = append(change.fields, path)
+	}
+
+	if len(change.fields) > 0 {
+		if _, err := srv.productClient.UpdateProduct(ctx, arg); err != nil {
+			return productChange{}, err
+		}
+	}
+	if change.inventoryDelta != 0 {
+		err := srv.shiftInventory(ctx, supplierID, product.GetProductId(), change.inventoryDelta, "batch update")
+		if err != nil {
+			// don't leave the update half applied
+			if len(change.fields) > 0 {
+				undo := productChange{before: product, fields: change.fields}
+				if err := srv.restoreProduct(ctx, supplierID, undo); err != nil {
+					log.Printf("can't restore product %d: %v", product.GetProductId(), err)
+				}
+			}
+			return productChange{}, err
+		}
+	}
+
+	return change, nil
+}
+
+// restoreProduct undoes change. The product is read again and only the
+// changed fields are reset, so changes made by others since are kept.
+func (srv *ShopService) restoreProduct(ctx context.Context, supplierID int64, change productChange) error {
+	before := change.before
+	if len(change.fields) > 0 {
+		err := retryTransient(ctx, func() error {
+			product, err := srv.productClient.GetProduct(ctx, &pb.GetProductRequest{
+				ProductId: before.GetProductId(),
+			})
+			if err != nil {
+				return err
+			}
+
+			arg := productUpdateParams(supplierID, product)
+			for _, path := range change.fields {
+				setProductField(arg, path, before.GetName(), before.GetPrice(), before.GetThumbnail(), before.GetBrand())
+			}
+			_, err = srv.productClient.UpdateProduct(ctx, arg)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// not retried, a lost response could apply the delta twice
+	if change.inventoryDelta != 0 {
+		return srv.shiftInventory(ctx, supplierID, before.GetProductId(), -change.inventoryDelta, "batch update rolled back")
+	}
+
+	return nil
+}
+
+// setProductField sets the field of arg named by path, other than the
+// inventory, to the matching value
+func setProductField(arg *pb.UpdateProductRequest, path string, name string, price int64, thumbnail string, brand string) {
+	switch path {
+	case productName:
+		arg.Name = strings.TrimSpace(name)
+	case productPrice:
+		arg.Price = price
+	case productThumbnail:
+		arg.Thumbnail = thumbnail
+	case productBrand:
+		arg.Brand = brand
+	}
+}
+
+// productUpdateParams copies product into a full update. UpdateProduct
+// replaces the stock too, so product must be read right before the update.
+func productUpdateParams(supplierID int64, product *pb.Product) *pb.UpdateProductRequest {
+	return &pb.UpdateProductRequest{
+		ProductId:  product.GetProductId(),
+		Name:       product.GetName(),
+		Price:      product.GetPrice(),
+		Thumbnail:  product.GetThumbnail(),
+		Inventory:  int64(product.GetInventory()),
+		Brand:      product.GetBrand(),
+		SupplierId: supplierID,
+	}
+}
+
+func validateProductPatch(patch *pb.ProductPatch, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case productName:
+			if strings.TrimSpace(patch.GetName()) == "" {
+				return status.Error(codes.InvalidArgument, "Vui lòng điền tên sản phẩm")
+			}
+		case productPrice:
+			if patch.GetPrice() <= 0 {
+				return status.Error(codes.InvalidArgument, "Giá sản phẩm phải lớn hơn 0")
+			}
+		case productInventory:
+			if patch.GetInventory() < 0 {
+				return status.Error(codes.InvalidArgument, "Số lượng tồn kho không được âm")
+			}
+		case productThumbnail, productBrand:
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown product field %q", path)
+		}
+	}
+
+	return nil
+}
+
+func productUpdateResult(productID int64, err error) *pb.ProductUpdateResult {
+	result := &pb.ProductUpdateResult{
+		ProductId: productID,
+		Ok:        err == nil,
+	}
+	if err != nil {
+		result.Error = status.Convert(err).Message()
+	}
+
+	return result
+}
@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// draft status values, stored by name in product_draft.status
+var (
+	draftScheduled     = pb.DraftStatus_scheduled.String()
+	draftPublished     = pb.DraftStatus_published.String()
+	draftPublishFailed = pb.DraftStatus_publish_failed.String()
+)
+
+const (
+	draftMaxAttempts  = 5
+	draftRetryBackoff = time.Minute
+	draftMaxBackoff   = time.Hour
+	draftPublishBatch = 50
+	// drafts publishing for this long were interrupted by a crash
+	draftStaleAfter = 5 * time.Minute
+)
+
+// SaveDraft creates a draft, or updates one that isn't published yet.
+// Drafts may be incomplete, they are only fully validated when published or
+// scheduled.
+func (srv *ShopService) SaveDraft(ctx context.Context, req *pb.SaveDraftRequest) (*pb.ProductDraft, error) {
+	product := req.GetProduct()
+	if product.GetPrice() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Giá sản phẩm không được âm")
+	}
+	if product.GetInventory() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Số lượng tồn kho không được âm")
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft repository.ProductDraft
+	if req.GetDraftId() == 0 {
+		draft, err = srv.shopStore.CreateProductDraft(ctx, repository.CreateProductDraftParams{
+			ShopID:      shop.ID,
+			SellerID:    me.ID,
+			CategoryID:  product.GetCategoryId(),
+			Name:        strings.TrimSpace(product.GetProductName()),
+			Description: product.GetDesc(),
+			Price:       product.GetPrice(),
+			Thumbnail:   product.GetThumbnailDataChunk(),
+			Inventory:   product.GetInventory(),
+			Brand:       product.GetBrand(),
+		})
+	} else {
+		draft, err = srv.shopStore.UpdateProductDraft(ctx, repository.UpdateProductDraftParams{
+			ID:          req.GetDraftId(),
+			SellerID:    me.ID,
+			CategoryID:  product.GetCategoryId(),
+			Name:        strings.TrimSpace(product.GetProductName()),
+			Description: product.GetDesc(),
+			Price:       product.GetPrice(),
+			Thumbnail:   product.GetThumbnailDataChunk(),
+			Inventory:   product.GetInventory(),
+			Brand:       product.GetBrand(),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, srv.draftConflict(ctx, req.GetDraftId(), me.ID)
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't save draft: %v", err)
+	}
+
+	return productDraft(draft), nil
+}
+
+// ListDrafts returns the drafts of the caller, newest first
+func (srv *ShopService) ListDrafts(ctx context.Context, req *pb.ListDraftsRequest) (*pb.ListDraftsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]string, 0, len(req.GetStatuses()))
+	for _, s := range req.GetStatuses() {
+		statuses = append(statuses, s.String())
+	}
+
+	limit := pageLimit(req.GetLimit())
+	drafts, err := srv.shopStore.ListProductDrafts(ctx, repository.ListProductDraftsParams{
+		SellerID: me.ID,
+		Statuses: statuses,
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list drafts: %v", err)
+	}
+
+	resp := &pb.ListDraftsResponse{
+		Drafts: make([]*pb.ProductDraft, 0, len(drafts)),
+	}
+	for _, draft := range drafts {
+		resp.Drafts = append(resp.Drafts, productDraft(draft))
+	}
+	if len(drafts) == int(limit) {
+		resp.NextCursor = drafts[len(drafts)-1].ID
+	}
+
+	return resp, nil
+}
+
+// PublishDraft creates the product of a draft right away
+func (srv *ShopService) PublishDraft(ctx context.Context, req *pb.PublishDraftRequest) (*pb.ProductDraft, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopOpen(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	if err := srv.validateDraft(ctx, req.GetDraftId(), me.ID); err != nil {
+		return nil, err
+	}
+
+	draft, err := srv.shopStore.ClaimProductDraft(ctx, repository.ClaimProductDraftParams{
+		ID:       req.GetDraftId(),
+		SellerID: me.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, srv.draftConflict(ctx, req.GetDraftId(), me.ID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't claim draft: %v", err)
+	}
+
+	draft, err = srv.publishDraft(ctx, draft, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return productDraft(draft), nil
+}
+
+// ScheduleDraft sets the time a draft gets published at
+func (srv *ShopService) ScheduleDraft(ctx context.Context, req *pb.ScheduleDraftRequest) (*pb.ProductDraft, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft repository.ProductDraft
+	if req.GetPublishAt() == nil {
+		draft, err = srv.shopStore.UnscheduleProductDraft(ctx, repository.UnscheduleProductDraftParams{
+			ID:       req.GetDraftId(),
+			SellerID: me.ID,
+		})
+	} else {
+		publishAt := req.GetPublishAt().AsTime()
+		if !publishAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "Thời gian đăng phải ở trong tương lai")
+		}
+		if err := srv.checkShopActive(ctx, me.ID); err != nil {
+			return nil, err
+		}
+		if err := srv.validateDraft(ctx, req.GetDraftId(), me.ID); err != nil {
+			return nil, err
+		}
+
+		draft, err = srv.shopStore.ScheduleProductDraft(ctx, repository.ScheduleProductDraftParams{
+			PublishAt: sql.NullTime{
+				Time:  publishAt,
+				Valid: true,
+			},
+			ID:       req.GetDraftId(),
+			SellerID: me.ID,
+		})
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, srv.draftConflict(ctx, req.GetDraftId(), me.ID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't schedule draft: %v", err)
+	}
+
+	return productDraft(draft), nil
+}
+
+// PublishScheduledDrafts publishes the drafts that are due on an interval,
+// until ctx is done
+func (srv *ShopService) PublishScheduledDrafts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		srv.publishScheduledDrafts(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *ShopService) publishScheduledDrafts(ctx context.Context) {
+	// product-service doesn't say whether an interrupted CreateProduct went
+	// through, so let the seller check rather than risk a duplicate product
+	_, err := srv.shopStore.FailStaleProductDrafts(ctx, repository.FailStaleProductDraftsParams{
+		LastError:   "publishing was interrupted, check your products before publishing again",
+		StaleBefore: time.Now().Add(-draftStaleAfter),
+	})
+	if err != nil {
+		log.Println("can't fail stale drafts: ", err)
+	}
+
+	drafts, err := srv.shopStore.ClaimDueProductDrafts(ctx, draftPublishBatch)
+	if err != nil {
+		log.Println("can't claim due drafts: ", err)
+		return
+	}
+
+	for _, draft := range drafts {
+		if _, err := srv.publishDraft(ctx, draft, true); err != nil {
+			log.Printf("publish draft %d: %v", draft.ID, err)
+		}
+	}
+}
+
+// publishDraft creates the product of a claimed draft and records the
+// outcome. With retry, transient failures reschedule the draft with an
+// exponential backoff, and a draft of a closed shop waits for it to open.
+func (srv *ShopService) publishDraft(ctx context.Context, draft repository.ProductDraft, retry bool) (repository.ProductDraft, error) {
+	err := srv.checkShopActive(ctx, draft.SellerID)
+	if err == nil {
+		var availability *pb.ShopAvailability
+		availability, err = srv.sellerAvailability(ctx, draft.SellerID)
+		// a due draft waits for its shop to open rather than failing
+		if err == nil && retry && !availability.GetAcceptingOrders() && availability.GetNextOpenAt() != nil {
+			return srv.postponeDraft(ctx, draft, availability.GetNextOpenAt().AsTime())
+		}
+		if err == nil {
+			err = shopClosedError(availability)
+		}
+	}
+	// a scheduled draft may have been edited since it was validated
+	if err == nil {
+		err = srv.checkDraftComplete(ctx, draft)
+	}
+	if err == nil {
+		_, err = srv.productClient.CreateProduct(ctx, draftProduct(draft))
+	}
+
+	arg := repository.FinishProductDraftParams{
+		ID:     draft.ID,
+		Status: draftPublished,
+	}
+	if err != nil {
+		arg.Status = draftPublishFailed
+		arg.LastError = status.Convert(err).Message()
+		if retry && isTransient(err) && draft.Attempts < draftMaxAttempts {
+			arg.Status = draftScheduled
+			arg.NextAttemptAt = sql.NullTime{
+				Time:  time.Now().Add(draftBackoff(draft.Attempts)),
+				Valid: true,
+			}
+		}
+	}
+
+	finished, finishErr := srv.shopStore.FinishProductDraft(ctx, arg)
+	if finishErr != nil {
+		return draft, status.Errorf(codes.Internal, "can't record publishing of draft %d: %v", draft.ID, finishErr)
+	}
+
+	return finished, err
+}
+
+// postponeDraft puts a claimed draft back on schedule for publishAt,
+// without counting the attempt
+func (srv *ShopService) postponeDraft(ctx context.Context, draft repository.ProductDraft, publishAt time.Time) (repository.ProductDraft, error) {
+	postponed, err := srv.shopStore.PostponeProductDraft(ctx, repository.PostponeProductDraftParams{
+		ID: draft.ID,
+		NextAttemptAt: sql.NullTime{
+			Time:  publishAt,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return draft, status.Errorf(codes.Internal, "can't postpone draft %d: %v", draft.ID, err)
+	}
+
+	return postponed, nil
+}
+
+// draftBackoff is the wait before the next attempt after attempts failures
+func draftBackoff(attempts int32) time.Duration {
+	backoff := draftRetryBackoff
+	for i := int32(1); i < attempts && backoff < draftMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > draftMaxBackoff {
+		backoff = draftMaxBackoff
+	}
+
+	return backoff
+}
+
+// validateDraft checks a draft is complete enough to be published
+func (srv *ShopService) validateDraft(ctx context.Context, id int64, sellerID int64) error {
+	draft, err := srv.shopStore.GetProductDraft(ctx, repository.GetProductDraftParams{
+		ID:       id,
+		SellerID: sellerID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "Bản nháp không tồn tại")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't get draft: %v", err)
+	}
+
+	return srv.checkDraftComplete(ctx, draft)
+}
+
+// checkDraftComplete checks the content of a draft can be published
+func (srv *ShopService) checkDraftComplete(ctx context.Context, draft repository.ProductDraft) error {
+	categories, err := srv.knownCategories(ctx)
+	if err != nil {
+		return err
+	}
+	err = validateImportRecord(importRecord{
+		CategoryID: draft.CategoryID,
+		Name:       draft.Name,
+		Price:      draft.Price,
+		Inventory:  draft.Inventory,
+	}, categories)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "draft is incomplete: %v", err)
+	}
+
+	return nil
+}
+
+// draftConflict explains why a draft couldn't be changed
+func (srv *ShopService) draftConflict(ctx context.Context, id int64, sellerID int64) error {
+	draft, err := srv.shopStore.GetProductDraft(ctx, repository.GetProductDraftParams{
+		ID:       id,
+		SellerID: sellerID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "Bản nháp không tồn tại")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't get draft: %v", err)
+	}
+
+	return status.Errorf(codes.FailedPrecondition, "can't change a %s draft", draft.Status)
+}
+
+func draftProduct(draft repository.ProductDraft) *pb.CreateProductRequest {
+	return &pb.CreateProductRequest{
+		SupplierId:         draft.SellerID,
+		CategoryId:         draft.CategoryID,
+		ProductName:        draft.Name,
+		Desc:               draft.Description,
+		Price:              draft.Price,
+		ThumbnailDataChunk: draft.Thumbnail,
+		Inventory:          draft.Inventory,
+		Brand:              draft.Brand,
+	}
+}
+
+func productDraft(draft repository.ProductDraft) *pb.ProductDraft {
+	resp := &pb.ProductDraft{
+		Id:        draft.ID,
+		Product:   draftProduct(draft),
+		Status:    pb.DraftStatus(pb.DraftStatus_value[draft.Status]),
+		Attempts:  draft.Attempts,
+		LastError: draft.LastError,
+		CreatedAt: timestamppb.New(draft.CreatedAt),
+		UpdatedAt: timestamppb.New(draft.UpdatedAt),
+	}
+	if draft.PublishAt.Valid {
+		resp.PublishAt = timestamppb.New(draft.PublishAt.Time)
+	}
+	if draft.NextAttemptAt.Valid {
+		resp.NextAttemptAt = timestamppb.New(draft.NextAttemptAt.Time)
+	}
+
+	return resp
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportColumn is a product field that can be exported
+type exportColumn struct {
+	name  string
+	value func(product *pb.Product) interface{}
+}
+
+// exportColumns in their default order. Shared columns are named like the
+// import columns, but an export is not an update: importing it again creates
+// every product anew, since the import ignores product_id, and without
+// thumbnails.
+var exportColumns = []exportColumn{
+	{"product_id", func(p *pb.Product) interface{} { return p.GetProductId() }},
+	{"name", func(p *pb.Product) interface{} { return p.GetName() }},
+	{"desc", func(p *pb.Product) interface{} { return p.GetDesc() }},
+	{"price", func(p *pb.Product) interface{} { return p.GetPrice() }},
+	{"inventory", func(p *pb.Product) interface{} { return p.GetInventory() }},
+	{"brand", func(p *pb.Product) interface{} { return p.GetBrand() }},
+	{"category_id", func(p *pb.Product) interface{} { return p.GetCategoryId() }},
+	{"total_sold", func(p *pb.Product) interface{} { return p.GetTotalSold() }},
+	{"star_average", func(p *pb.Product) interface{} { return p.GetStarAverage() }},
+}
+
+// ExportProducts streams the catalog of the caller as CSV or JSON Lines, one
+// chunk per page of products
+func (srv *ShopService) ExportProducts(req *pb.ExportProductsRequest, stream pb.ShopService_ExportProductsServer) error {
+	ctx := stream.Context()
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	columns, err := selectExportColumns(req.GetColumns())
+	if err != nil {
+		return err
+	}
+
+	var encode func(buf *bytes.Buffer, products []*pb.Product) error
+	switch req.GetFormat() {
+	case pb.ProductFileFormat_csv:
+		encode = func(buf *bytes.Buffer, products []*pb.Product) error {
+			return encodeCSVExport(buf, columns, products)
+		}
+	case pb.ProductFileFormat_jsonl:
+		encode = func(buf *bytes.Buffer, products []*pb.Product) error {
+			return encodeJSONLExport(buf, columns, products)
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown format %v", req.GetFormat())
+	}
+
+	var buf bytes.Buffer
+	if req.GetFormat() == pb.ProductFileFormat_csv {
+		header := make([]string, 0, len(columns))
+		for _, column := range columns {
+			header = append(header, column.name)
+		}
+		writer := csv.NewWriter(&buf)
+		writer.Write(header)
+		writer.Flush()
+	}
+
+	for page := 0; ; page++ {
+		if page == supplierProductMaxPages {
+			return status.Errorf(codes.FailedPrecondition, "catalog has more than %d products", supplierProductMaxPages*supplierProductPageSize)
+		}
+
+		// a stable order, so products don't move between pages
+		resp, err := srv.productClient.GetProductBySupplier(ctx, &pb.GetProductBySupplierRequest{
+			SupplierId: me.ID,
+			Limit:      supplierProductPageSize,
+			Offset:     int32(page * supplierProductPageSize),
+			ByTime:     true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := encode(&buf, resp.GetListProduct()); err != nil {
+			return status.Errorf(codes.Internal, "can't encode products: %v", err)
+		}
+		if buf.Len() > 0 {
+			err = stream.Send(&pb.ExportProductsResponse{
+				Chunk: buf.Bytes(),
+			})
+			if err != nil {
+				return err
+			}
+			buf = bytes.Buffer{}
+		}
+
+		if len(resp.GetListProduct()) < supplierProductPageSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// selectExportColumns resolves the requested column names, defaulting to
+// every column
+func selectExportColumns(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		return exportColumns, nil
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+
+		column, ok := findExportColumn(name)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown column %q", name)
+		}
+		seen[name] = true
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func findExportColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+func encodeCSVExport(buf *bytes.Buffer, columns []exportColumn, products []*pb.Product) error {
+	writer := csv.NewWriter(buf)
+	record := make([]string, len(columns))
+	for _, product := range products {
+		for i, column := range columns {
+			value := column.value(product)
+			if text, ok := value.(string); ok {
+				record[i] = escapeCSVFormula(text)
+			} else {
+				record[i] = fmt.Sprint(value)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// escapeCSVFormula keeps spreadsheets from evaluating text cells, a seller
+// controlled name like =HYPERLINK(...) would otherwise run when opened
+func escapeCSVFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// encodeJSONLExport writes one object per product, keys in column order
+func encodeJSONLExport(buf *bytes.Buffer, columns []exportColumn, products []*pb.Product) error {
+	for _, product := range products {
+		buf.WriteByte('{')
+		for i, column := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(column.name)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(column.value(product))
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+	}
+
+	return nil
+}
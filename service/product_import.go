@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxImportSize = 10 << 20 // 10MB
+	maxImportRows = 5000
+	// concurrent CreateProduct calls per import
+	importConcurrency = 4
+)
+
+// importRecord is a row of an import file. CSV files name these fields in
+// their header, JSON Lines files use them as keys.
+type importRecord struct {
+	CategoryID int64  `json:"category_id"`
+	Name       string `json:"name"`
+	Desc       string `json:"desc"`
+	Price      int64  `json:"price"`
+	Inventory  int64  `json:"inventory"`
+	Brand      string `json:"brand"`
+	Thumbnail  string `json:"thumbnail"`
+}
+
+var requiredImportColumns = []string{"name", "price", "category_id"}
+
+type importRow struct {
+	line   int
+	record importRecord
+	err    error
+}
+
+// ImportProducts creates products in bulk from a CSV or JSON Lines file sent
+// as an ImportOptions message followed by the file in chunks. Every row is
+// validated and reported on separately, so one bad row doesn't fail the
+// whole import.
+func (srv *ShopService) ImportProducts(stream pb.ShopService_ImportProductsServer) error {
+	ctx := stream.Context()
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "can't receive import options: %v", err)
+	}
+	options := req.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+	// a dry run lists nothing, let sellers prepare an import while closed
+	if !options.GetDryRun() {
+		if err := srv.checkShopOpen(ctx, me.ID); err != nil {
+			return err
+		}
+	}
+
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Unknown, "can't receive import chunk: %v", err)
+		}
+
+		if data.Len()+len(req.GetChunk()) > maxImportSize {
+			return status.Errorf(codes.InvalidArgument, "File nhập không được vượt quá %dMB", maxImportSize>>20)
+		}
+		data.Write(req.GetChunk())
+	}
+
+	var rows []importRow
+	switch options.GetFormat() {
+	case pb.ProductFileFormat_csv:
+		rows, err = parseCSVImport(data.Bytes())
+	case pb.ProductFileFormat_jsonl:
+		rows, err = parseJSONLImport(data.Bytes())
+	default:
+		err = fmt.Errorf("unknown format %v", options.GetFormat())
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "can't parse import file: %v", err)
+	}
+	if len(rows) > maxImportRows {
+		return status.Errorf(codes.InvalidArgument, "Mỗi lần chỉ được nhập tối đa %d sản phẩm", maxImportRows)
+	}
+
+	categories, err := srv.knownCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*pb.ImportRowResult, len(rows))
+	for i, row := range rows {
+		if row.err == nil {
+			row.err = validateImportRecord(row.record, categories)
+		}
+		if row.err != nil || options.GetDryRun() {
+			results[i] = importResult(row, row.err)
+		}
+	}
+
+	if !options.GetDryRun() {
+		sem := make(chan struct{}, importConcurrency)
+		var wg sync.WaitGroup
+		for i, row := range rows {
+			if results[i] != nil {
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, row importRow) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				_, err := srv.productClient.CreateProduct(ctx, &pb.CreateProductRequest{
+					SupplierId:         me.ID,
+					CategoryId:         row.record.CategoryID,
+					ProductName:        row.record.Name,
+					Desc:               row.record.Desc,
+					Price:              row.record.Price,
+					ThumbnailDataChunk: row.record.Thumbnail,
+					Inventory:          row.record.Inventory,
+					Brand:              row.record.Brand,
+				})
+				results[i] = importResult(row, err)
+			}(i, row)
+		}
+		wg.Wait()
+	}
+
+	resp := &pb.ImportProductsResponse{
+		Total:   int32(len(results)),
+		Results: results,
+	}
+	for _, result := range results {
+		if result.GetOk() {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func importResult(row importRow, err error) *pb.ImportRowResult {
+	result := &pb.ImportRowResult{
+		Line:        int32(row.line),
+		Ok:          err == nil,
+		ProductName: row.record.Name,
+	}
+	if err != nil {
+		result.Error = status.Convert(err).Message()
+	}
+
+	return result
+}
+
+func validateImportRecord(record importRecord, categories map[int64]bool) error {
+	if strings.TrimSpace(record.Name) == "" {
+		return errors.New("name is required")
+	}
+	if record.Price <= 0 {
+		return errors.New("price must be greater than 0")
+	}
+	if record.Inventory < 0 {
+		return errors.New("inventory can't be negative")
+	}
+	if !categories[record.CategoryID] {
+		return fmt.Errorf("unknown category %d", record.CategoryID)
+	}
+
+	return nil
+}
+
+// parseCSVImport reads a CSV file whose header names the importRecord fields
+func parseCSVImport(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("can't read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		// a malformed record only fails its own row, reading resumes on the
+		// next line. A quote error can swallow the lines after it instead,
+		// which would drop those rows unreported, so it fails the file.
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if errors.Is(err, csv.ErrQuote) || parseErr.StartLine != parseErr.Line {
+				return nil, err
+			}
+			rows = append(rows, importRow{line: parseErr.StartLine, err: err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{line: line}
+		if len(record) != len(header) {
+			row.err = fmt.Errorf("expected %d columns, got %d", len(header), len(record))
+			rows = append(rows, row)
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row.record = importRecord{
+			Name:      get("name"),
+			Desc:      get("desc"),
+			Brand:     get("brand"),
+			Thumbnail: get("thumbnail"),
+		}
+		row.record.CategoryID, row.err = parseImportInt(get("category_id"), "category_id", row.err)
+		row.record.Price, row.err = parseImportInt(get("price"), "price", row.err)
+		row.record.Inventory, row.err = parseImportInt(get("inventory"), "inventory", row.err)
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseImportInt parses an optional integer column, keeping the first error
+// of the row
+func parseImportInt(value string, column string, rowErr error) (int64, error) {
+	if value == "" {
+		return 0, rowErr
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil && rowErr == nil {
+		rowErr = fmt.Errorf("invalid %s %q", column, value)
+	}
+	return n, rowErr
+}
+
+// parseJSONLImport reads one JSON object per line, skipping blank lines
+func parseJSONLImport(data []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64<<10), maxImportSize)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := importRow{line: line}
+		if err := json.Unmarshal(text, &row.record); err != nil {
+			row.err = fmt.Errorf("invalid json: %v", err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
@@ -0,0 +1,121 @@
+package service
+
+import "testing"
+
+func TestParseCSVImport(t *testing.T) {
+	type wantRow struct {
+		line  int
+		name  string
+		price int64
+		err   bool
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []wantRow
+		wantErr bool
+	}{
+		{
+			name: "valid rows",
+			data: "name,price,category_id\na,1,1\nb,2,1\n",
+			want: []wantRow{
+				{line: 2, name: "a", price: 1},
+				{line: 3, name: "b", price: 2},
+			},
+		},
+		{
+			name: "header columns in any case and order",
+			data: "Category_ID, Price ,NAME\n1,5,a\n",
+			want: []wantRow{
+				{line: 2, name: "a", price: 5},
+			},
+		},
+		{
+			name: "quoted field spanning lines",
+			data: "name,price,category_id\n\"a\nb\",1,1\nc,2,1\n",
+			want: []wantRow{
+				{line: 2, name: "a\nb", price: 1},
+				{line: 4, name: "c", price: 2},
+			},
+		},
+		{
+			name: "bad values only fail their row",
+			data: "name,price,category_id\na,x,1\nb,2,1\n",
+			want: []wantRow{
+				{line: 2, name: "a", err: true},
+				{line: 3, name: "b", price: 2},
+			},
+		},
+		{
+			name: "wrong column count only fails its row",
+			data: "name,price,category_id\na,1\nb,2,1\n",
+			want: []wantRow{
+				{line: 2, err: true},
+				{line: 3, name: "b", price: 2},
+			},
+		},
+		{
+			name: "bare quote only fails its row",
+			data: "name,price,category_id\na\"b,1,1\nc,2,1\n",
+			want: []wantRow{
+				{line: 2, err: true},
+				{line: 3, name: "c", price: 2},
+			},
+		},
+		{
+			name:    "unterminated quote fails the file",
+			data:    "name,price,category_id\na,1,1\n\"b,2,1\nc,3,1\nd,4,1",
+			wantErr: true,
+		},
+		{
+			name:    "text after a closing quote fails the file",
+			data:    "name,price,category_id\n\"a\"b,1,1\nc,2,1\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing required column",
+			data:    "name,category_id\na,1\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			data:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseCSVImport([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCSVImport() = %d rows, want error", len(rows))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCSVImport() error = %v", err)
+			}
+
+			if len(rows) != len(tt.want) {
+				t.Fatalf("parseCSVImport() = %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				row := rows[i]
+				if row.line != want.line {
+					t.Errorf("row %d line = %d, want %d", i, row.line, want.line)
+				}
+				if (row.err != nil) != want.err {
+					t.Errorf("row %d error = %v, want error %v", i, row.err, want.err)
+				}
+				if want.name != "" && row.record.Name != want.name {
+					t.Errorf("row %d name = %q, want %q", i, row.record.Name, want.name)
+				}
+				if !want.err && row.record.Price != want.price {
+					t.Errorf("row %d price = %d, want %d", i, row.record.Price, want.price)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Shop registration spans user-service (supplier role) and the shop table, so
+// it runs as a saga persisted in shop_registration_saga:
+//
+//	pending -> role_granted -> shop_created -> done
+//	   |  \            \
+//	   |   +------------+-> compensating -> failed
+//	   |                         \
+//	   +-> failed                 +-> abandoned
+//
+// A pending saga whose grant was refused outright fails without compensation,
+// since there is no role of ours to revoke. A seller that already is a
+// supplier goes straight to role_granted, and the saga is flagged so its
+// compensation leaves that role alone. A saga whose revoke keeps failing
+// is abandoned: the seller may still hold the supplier role, and an operator
+// must check the seller has no shop before revoking it by hand.
+//
+// Every transition is a compare-and-set on the current state, so a request
+// and the recoverer can never both drive the same saga forward.
+const (
+	sagaPending      = "pending"
+	sagaRoleGranted  = "role_granted"
+	sagaShopCreated  = "shop_created"
+	sagaDone         = "done"
+	sagaCompensating = "compensating"
+	sagaFailed       = "failed"
+	sagaAbandoned    = "abandoned"
+)
+
+const (
+	sagaMaxRetries   = 3
+	sagaRetryBackoff = 200 * time.Millisecond
+	// failed steps recorded on a saga before its compensation is abandoned
+	sagaMaxAttempts = 10
+	// sagas untouched for this long are considered interrupted
+	sagaStaleAfter   = time.Minute
+	sagaRecoverBatch = 50
+)
+
+var errSagaConflict = errors.New("registration saga was advanced by another worker")
+
+// runRegistrationSaga drives saga forward until it is done or compensated
+func (srv *ShopService) runRegistrationSaga(ctx context.Context, saga *repository.ShopRegistrationSaga) error {
+	for {
+		switch saga.State {
+		case sagaPending:
+			if !saga.RolePreexisting {
+				err := retryTransient(ctx, func() error {
+					_, err := srv.userClient.SupplierRegister(ctx, _empty)
+					return err
+				})
+				if status.Code(err) == codes.AlreadyExists {
+					// a supplier without a shop, e.g. whose shop was removed
+					if err := srv.shopStore.MarkRegistrationSagaRolePreexisting(ctx, saga.ID); err != nil {
+						return fmt.Errorf("can't mark saga %d role preexisting: %w", saga.ID, err)
+					}
+					saga.RolePreexisting = true
+					err = nil
+				}
+				if err != nil && !mayHaveGranted(err) {
+					return srv.failRegistration(ctx, saga, err)
+				}
+				if err != nil {
+					return srv.compensateRegistration(ctx, saga, err)
+				}
+			}
+			if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaRoleGranted); err != nil {
+				return err
+			}
+			saga.State = sagaRoleGranted
+
+		case sagaRoleGranted:
+			err := srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+				shop, err := q.GetShopBySellerID(ctx, saga.SellerID)
+				if errors.Is(err, sql.ErrNoRows) {
+					shop, err = q.CreateShop(ctx, repository.CreateShopParams{
+						SellerID: saga.SellerID,
+						Name:     saga.Name,
+						Avatar:   saga.Avatar,
+					})
+				}
+				if err != nil {
+					return err
+				}
+
+				err = q.AddShopCategories(ctx, repository.AddShopCategoriesParams{
+					ShopID:      shop.ID,
+					CategoryIds: saga.CategoryIds,
+				})
+				if err != nil {
+					return err
+				}
+
+				return srv.transitionSaga(ctx, q, saga, sagaShopCreated)
+			})
+			if errors.Is(err, errSagaConflict) {
+				return err
+			}
+			if err != nil {
+				return srv.compensateRegistration(ctx, saga, err)
+			}
+			saga.State = sagaShopCreated
+
+		case sagaShopCreated:
+			if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaDone); err != nil {
+				return err
+			}
+			saga.State = sagaDone
+
+		case sagaCompensating:
+			return srv.compensateRegistration(ctx, saga, errors.New("registration was interrupted"))
+
+		case sagaDone:
+			return nil
+
+		default:
+			return status.Error(codes.Aborted, "Đăng kí cửa hàng thất bại, vui lòng thử lại")
+		}
+	}
+}
+
+// failRegistration marks a saga that never got the supplier role failed
+func (srv *ShopService) failRegistration(ctx context.Context, saga *repository.ShopRegistrationSaga, cause error) error {
+	srv.recordSagaError(ctx, saga, cause)
+
+	if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaFailed); err != nil {
+		return err
+	}
+	saga.State = sagaFailed
+
+	return registrationError(cause)
+}
+
+// compensateRegistration revokes the supplier role granted by the saga and
+// marks it failed. When the revoke fails with a transient error the saga
+// stays in compensating so the recoverer can retry it later, until it runs
+// out of attempts and is abandoned.
+func (srv *ShopService) compensateRegistration(ctx context.Context, saga *repository.ShopRegistrationSaga, cause error) error {
+	if saga.State != sagaCompensating {
+		if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaCompensating); err != nil {
+			return err
+		}
+		saga.State = sagaCompensating
+	}
+
+	// the seller keeps a role the saga didn't grant
+	var err error
+	if !saga.RolePreexisting {
+		err = retryTransient(ctx, func() error {
+			_, err := srv.userClient.SupplierRevoke(ctx, &pb.SupplierRevokeRequest{
+				SupplierId: saga.SellerID,
+			})
+			return err
+		})
+	}
+	if err != nil {
+		// one recorded error per round, attempts count rounds
+		srv.recordSagaError(ctx, saga, fmt.Errorf("%v, then revoke failed: %w", cause, err))
+		if isTransient(err) && saga.Attempts < sagaMaxAttempts {
+			return status.Errorf(codes.Unavailable, "Đăng kí cửa hàng thất bại do %v", cause)
+		}
+
+		log.Printf("abandon registration saga %d of seller %d, its supplier role must be revoked by hand: %v", saga.ID, saga.SellerID, err)
+		if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaAbandoned); err != nil {
+			return err
+		}
+		saga.State = sagaAbandoned
+
+		return registrationError(cause)
+	}
+
+	srv.recordSagaError(ctx, saga, cause)
+	if err := srv.transitionSaga(ctx, srv.shopStore.Queries, saga, sagaFailed); err != nil {
+		return err
+	}
+	saga.State = sagaFailed
+
+	return registrationError(cause)
+}
+
+func registrationError(cause error) error {
+	if _, ok := status.FromError(cause); ok {
+		return cause
+	}
+	return status.Errorf(codes.Internal, "Đăng kí cửa hàng thất bại do %v", cause)
+}
+
+// mayHaveGranted reports whether SupplierRegister may have granted the role
+// despite failing with err. Only an outright refusal rules it out,
+// AlreadyExists is handled before as an existing supplier.
+func mayHaveGranted(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented, codes.Unauthenticated:
+		return false
+	}
+	return true
+}
+
+func (srv *ShopService) transitionSaga(ctx context.Context, q *repository.Queries, saga *repository.ShopRegistrationSaga, to string) error {
+	n, err := q.TransitionRegistrationSaga(ctx, repository.TransitionRegistrationSagaParams{
+		ID:        saga.ID,
+		FromState: saga.State,
+		ToState:   to,
+	})
+	if err != nil {
+		return fmt.Errorf("can't move saga %d from %s to %s: %w", saga.ID, saga.State, to, err)
+	}
+	if n == 0 {
+		return errSagaConflict
+	}
+
+	return nil
+}
+
+func (srv *ShopService) recordSagaError(ctx context.Context, saga *repository.ShopRegistrationSaga, cause error) {
+	err := srv.shopStore.RecordRegistrationSagaError(ctx, repository.RecordRegistrationSagaErrorParams{
+		ID: saga.ID,
+		LastError: sql.NullString{
+			String: cause.Error(),
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		log.Printf("can't record error of saga %d: %v", saga.ID, err)
+		return
+	}
+	saga.Attempts++
+}
+
+// RecoverRegistrationSagas resumes registrations interrupted by a crash or a
+// lost request every interval. It blocks until ctx is canceled.
+func (srv *ShopService) RecoverRegistrationSagas(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		srv.recoverRegistrationSagas(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *ShopService) recoverRegistrationSagas(ctx context.Context) {
+	sagas, err := srv.shopStore.ListStaleRegistrationSagas(ctx, repository.ListStaleRegistrationSagasParams{
+		StaleBefore: time.Now().Add(-sagaStaleAfter),
+		RowLimit:    sagaRecoverBatch,
+	})
+	if err != nil {
+		log.Println("can't list stale registration sagas: ", err)
+		return
+	}
+
+	for i := range sagas {
+		saga := &sagas[i]
+		if saga.State == sagaPending {
+			// granting the role needs the seller's own credentials, which only
+			// the original request carried, so roll it back instead
+			err = srv.compensateRegistration(ctx, saga, errors.New("registration was interrupted"))
+		} else {
+			err = srv.runRegistrationSaga(ctx, saga)
+		}
+		if err != nil && !errors.Is(err, errSagaConflict) {
+			log.Printf("recover registration saga %d: %v", saga.ID, err)
+		}
+	}
+}
+
+// retryTransient retries fn with exponential backoff as long as it fails
+// with an error worth retrying
+func retryTransient(ctx context.Context, fn func() error) error {
+	backoff := sagaRetryBackoff
+	var err error
+	for attempt := 0; attempt < sagaMaxRetries; attempt++ {
+		err = fn()
+		if err == nil || !isTransient(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}
+
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
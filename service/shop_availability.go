@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxVacationMessageLength = 500
+	minutesPerDay            = 24 * 60
+)
+
+// SetOpeningHours replaces the weekly opening hours of the caller's shop
+func (srv *ShopService) SetOpeningHours(ctx context.Context, req *pb.SetOpeningHoursRequest) (*pb.ShopSchedule, error) {
+	timezone := strings.TrimSpace(req.GetTimezone())
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Múi giờ %q không hợp lệ", timezone)
+	}
+	if err := validateOpeningHours(req.GetOpeningHours()); err != nil {
+		return nil, err
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		err := q.UpdateShopTimezone(ctx, repository.UpdateShopTimezoneParams{
+			ID:       shop.ID,
+			Timezone: timezone,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't update timezone: %v", err)
+		}
+
+		if err := q.ClearShopOpeningHours(ctx, shop.ID); err != nil {
+			return status.Errorf(codes.Internal, "can't clear opening hours: %v", err)
+		}
+		for _, hours := range req.GetOpeningHours() {
+			err := q.AddShopOpeningHours(ctx, repository.AddShopOpeningHoursParams{
+				ShopID:   shop.ID,
+				Weekday:  int16(hours.GetWeekday()),
+				OpensAt:  hours.GetOpensAt(),
+				ClosesAt: hours.GetClosesAt(),
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "can't add opening hours: %v", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	shop.Timezone = timezone
+
+	return srv.shopSchedule(ctx, shop)
+}
+
+// SetVacation sets or ends the vacation of the caller's shop
+func (srv *ShopService) SetVacation(ctx context.Context, req *pb.SetVacationRequest) (*pb.ShopSchedule, error) {
+	arg := repository.UpdateShopVacationParams{}
+	if vacation := req.GetVacation(); vacation != nil {
+		if vacation.GetStartsAt() == nil || vacation.GetEndsAt() == nil {
+			return nil, status.Error(codes.InvalidArgument, "starts_at and ends_at are required")
+		}
+		startsAt, endsAt := vacation.GetStartsAt().AsTime(), vacation.GetEndsAt().AsTime()
+		if !endsAt.After(startsAt) {
+			return nil, status.Error(codes.InvalidArgument, "Thời gian kết thúc phải sau thời gian bắt đầu")
+		}
+		if !endsAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "Thời gian kết thúc phải ở trong tương lai")
+		}
+		if utf8.RuneCountInString(vacation.GetMessage()) > maxVacationMessageLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Lời nhắn không được dài quá %d kí tự", maxVacationMessageLength)
+		}
+
+		arg.VacationStart = sql.NullTime{Time: startsAt, Valid: true}
+		arg.VacationEnd = sql.NullTime{Time: endsAt, Valid: true}
+		arg.VacationMessage = vacation.GetMessage()
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	arg.ID = shop.ID
+	shop, err = srv.shopStore.UpdateShopVacation(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't update vacation: %v", err)
+	}
+
+	return srv.shopSchedule(ctx, shop)
+}
+
+// GetShopAvailability tells whether a shop accepts orders right now
+func (srv *ShopService) GetShopAvailability(ctx context.Context, req *pb.GetShopAvailabilityRequest) (*pb.ShopAvailability, error) {
+	shop, err := srv.shopStore.GetShopByID(ctx, req.GetShopId())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+	if !shopVisible(shop) {
+		return &pb.ShopAvailability{
+			Reason: pb.AvailabilityReason_shop_unavailable,
+		}, nil
+	}
+
+	hours, err := srv.shopStore.ListShopOpeningHours(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list opening hours: %v", err)
+	}
+
+	availability := shopAvailability(shop, hours, time.Now())
+	availability.Schedule = shopSchedule(shop, hours)
+
+	return availability, nil
+}
+
+// checkShopOpen rejects new listings while the shop of sellerID doesn't
+// accept orders. Sellers without a shop, e.g. admins, are not restricted.
+func (srv *ShopService) checkShopOpen(ctx context.Context, sellerID int64) error {
+	availability, err := srv.sellerAvailability(ctx, sellerID)
+	if err != nil {
+		return err
+	}
+
+	return shopClosedError(availability)
+}
+
+// sellerAvailability works out whether the shop of sellerID accepts orders
+// right now, sellers without a shop always do
+func (srv *ShopService) sellerAvailability(ctx context.Context, sellerID int64) (*pb.ShopAvailability, error) {
+	shop, err := srv.shopStore.GetShopBySellerID(ctx, sellerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &pb.ShopAvailability{
+			AcceptingOrders: true,
+			Reason:          pb.AvailabilityReason_open_now,
+		}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	hours, err := srv.shopStore.ListShopOpeningHours(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list opening hours: %v", err)
+	}
+
+	return shopAvailability(shop, hours, time.Now()), nil
+}
+
+// shopClosedError is the error new listings get while availability doesn't
+// accept orders
+func shopClosedError(availability *pb.ShopAvailability) error {
+	switch availability.GetReason() {
+	case pb.AvailabilityReason_on_vacation:
+		return status.Error(codes.FailedPrecondition, "Cửa hàng đang trong kỳ nghỉ, không thể đăng sản phẩm mới")
+	case pb.AvailabilityReason_outside_opening_hours:
+		return status.Error(codes.FailedPrecondition, "Cửa hàng đang ngoài giờ mở cửa, không thể đăng sản phẩm mới")
+	}
+
+	return nil
+}
+
+func (srv *ShopService) shopSchedule(ctx context.Context, shop repository.Shop) (*pb.ShopSchedule, error) {
+	hours, err := srv.shopStore.ListShopOpeningHours(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list opening hours: %v", err)
+	}
+
+	return shopSchedule(shop, hours), nil
+}
+
+// shopAvailability works out whether shop accepts orders at now, and if
+// not, when it opens again
+func shopAvailability(shop repository.Shop, hours []repository.ShopOpeningHour, now time.Time) *pb.ShopAvailability {
+	availability := &pb.ShopAvailability{
+		AcceptingOrders: true,
+		Reason:          pb.AvailabilityReason_open_now,
+	}
+
+	switch {
+	case onVacation(shop, now):
+		availability.Reason = pb.AvailabilityReason_on_vacation
+		availability.Message = shop.VacationMessage
+	case !withinOpeningHours(hours, shopLocation(shop), now):
+		availability.Reason = pb.AvailabilityReason_outside_opening_hours
+	default:
+		return availability
+	}
+
+	availability.AcceptingOrders = false
+	if openAt, ok := nextOpenAt(shop, hours, now); ok {
+		availability.NextOpenAt = timestamppb.New(openAt)
+	}
+
+	return availability
+}
+
+func onVacation(shop repository.Shop, t time.Time) bool {
+	return shop.VacationStart.Valid && shop.VacationEnd.Valid &&
+		!t.Before(shop.VacationStart.Time) && t.Before(shop.VacationEnd.Time)
+}
+
+func withinOpeningHours(hours []repository.ShopOpeningHour, loc *time.Location, t time.Time) bool {
+	openAt, ok := nextOpeningTime(hours, loc, t)
+	return ok && openAt.Equal(t)
+}
+
+// nextOpenAt is the first time from t on when the shop is open and not on
+// vacation
+func nextOpenAt(shop repository.Shop, hours []repository.ShopOpeningHour, t time.Time) (time.Time, bool) {
+	loc := shopLocation(shop)
+	// the vacation is the only thing that can push the time past an
+	// opening, so it moves at most twice
+	for i := 0; i < 3; i++ {
+		if onVacation(shop, t) {
+			t = shop.VacationEnd.Time
+		}
+
+		openAt, ok := nextOpeningTime(hours, loc, t)
+		if !ok {
+			return time.Time{}, false
+		}
+		if !onVacation(shop, openAt) {
+			return openAt, true
+		}
+		t = openAt
+	}
+
+	return time.Time{}, false
+}
+
+// nextOpeningTime is the first time from t on that falls within the opening
+// hours, sorted by weekday and opening time as stored, or t itself when no
+// hours are set
+func nextOpeningTime(hours []repository.ShopOpeningHour, loc *time.Location, t time.Time) (time.Time, bool) {
+	if len(hours) == 0 {
+		return t, true
+	}
+
+	local := t.In(loc)
+	// a week and a day covers every weekday, including the rest of today
+	for offset := 0; offset <= 7; offset++ {
+		year, month, day := local.Year(), local.Month(), local.Day()+offset
+		// built from the wall clock rather than added to midnight, days a DST
+		// transition falls on aren't 24 hours long
+		weekday := time.Date(year, month, day, 12, 0, 0, 0, loc).Weekday()
+		for _, h := range hours {
+			if time.Weekday(h.Weekday) != weekday {
+				continue
+			}
+
+			opens := time.Date(year, month, day, 0, int(h.OpensAt), 0, 0, loc)
+			closes := time.Date(year, month, day, 0, int(h.ClosesAt), 0, 0, loc)
+			if closes.After(t) {
+				if opens.Before(t) {
+					return t, true
+				}
+				return opens, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func shopLocation(shop repository.Shop) *time.Location {
+	loc, err := time.LoadLocation(shop.Timezone)
+	if err != nil {
+		log.Printf("invalid timezone %q of shop %d: %v", shop.Timezone, shop.ID, err)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// validateOpeningHours checks every interval is within a day and none of
+// them overlap
+func validateOpeningHours(hours []*pb.OpeningHours) error {
+	sorted := make([]*pb.OpeningHours, len(hours))
+	copy(sorted, hours)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].GetWeekday() != sorted[j].GetWeekday() {
+			return sorted[i].GetWeekday() < sorted[j].GetWeekday()
+		}
+		return sorted[i].GetOpensAt() < sorted[j].GetOpensAt()
+	})
+
+	for i, h := range sorted {
+		if h.GetWeekday() < 0 || h.GetWeekday() > 6 {
+			return status.Errorf(codes.InvalidArgument, "invalid weekday %d", h.GetWeekday())
+		}
+		if h.GetOpensAt() < 0 || h.GetClosesAt() > minutesPerDay || h.GetOpensAt() >= h.GetClosesAt() {
+			return status.Error(codes.InvalidArgument, "Giờ mở cửa phải trước giờ đóng cửa trong cùng một ngày")
+		}
+		if i > 0 && sorted[i-1].GetWeekday() == h.GetWeekday() && sorted[i-1].GetClosesAt() > h.GetOpensAt() {
+			return status.Error(codes.InvalidArgument, "Các khung giờ mở cửa không được chồng lên nhau")
+		}
+	}
+
+	return nil
+}
+
+func shopSchedule(shop repository.Shop, hours []repository.ShopOpeningHour) *pb.ShopSchedule {
+	schedule := &pb.ShopSchedule{
+		Timezone:     shop.Timezone,
+		OpeningHours: make([]*pb.OpeningHours, 0, len(hours)),
+	}
+	for _, h := range hours {
+		schedule.OpeningHours = append(schedule.OpeningHours, &pb.OpeningHours{
+			Weekday:  int32(h.Weekday),
+			OpensAt:  h.OpensAt,
+			ClosesAt: h.ClosesAt,
+		})
+	}
+	if shop.VacationStart.Valid && shop.VacationEnd.Valid {
+		schedule.Vacation = &pb.Vacation{
+			StartsAt: timestamppb.New(shop.VacationStart.Time),
+			EndsAt:   timestamppb.New(shop.VacationEnd.Time),
+			Message:  shop.VacationMessage,
+		}
+	}
+
+	return schedule
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hour is a time of day in minutes
+func hour(h, m int32) int32 {
+	return h*60 + m
+}
+
+func openingHour(weekday time.Weekday, opensAt, closesAt int32) repository.ShopOpeningHour {
+	return repository.ShopOpeningHour{
+		Weekday:  int16(weekday),
+		OpensAt:  opensAt,
+		ClosesAt: closesAt,
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+// 2026-10-19 is a Monday
+func onWeekOf19Oct(day int, h, m int, loc *time.Location) time.Time {
+	return time.Date(2026, time.October, day, h, m, 0, 0, loc)
+}
+
+func TestNextOpeningTime(t *testing.T) {
+	weekly := []repository.ShopOpeningHour{
+		openingHour(time.Monday, hour(9, 0), hour(17, 0)),
+		openingHour(time.Wednesday, hour(9, 0), hour(12, 0)),
+		openingHour(time.Wednesday, hour(13, 0), hour(17, 0)),
+		openingHour(time.Saturday, hour(10, 0), hour(24, 0)),
+	}
+	mondays := []repository.ShopOpeningHour{
+		openingHour(time.Monday, hour(9, 0), hour(17, 0)),
+	}
+	sundays := []repository.ShopOpeningHour{
+		openingHour(time.Sunday, hour(9, 0), hour(17, 0)),
+	}
+	utc := time.UTC
+	newYork := mustLoadLocation(t, "America/New_York")
+	saigon := mustLoadLocation(t, "Asia/Ho_Chi_Minh")
+
+	tests := []struct {
+		name  string
+		hours []repository.ShopOpeningHour
+		loc   *time.Location
+		t     time.Time
+		want  time.Time
+	}{
+		{
+			name: "no hours means always open",
+			loc:  utc,
+			t:    onWeekOf19Oct(20, 3, 0, utc),
+			want: onWeekOf19Oct(20, 3, 0, utc),
+		},
+		{
+			name:  "within hours",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 10, 0, utc),
+			want:  onWeekOf19Oct(19, 10, 0, utc),
+		},
+		{
+			name:  "before opening",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 8, 0, utc),
+			want:  onWeekOf19Oct(19, 9, 0, utc),
+		},
+		{
+			name:  "exactly at opening",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 9, 0, utc),
+			want:  onWeekOf19Oct(19, 9, 0, utc),
+		},
+		{
+			name:  "just before closing",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 17, 0, utc).Add(-time.Second),
+			want:  onWeekOf19Oct(19, 17, 0, utc).Add(-time.Second),
+		},
+		{
+			name:  "exactly at closing",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 17, 0, utc),
+			want:  onWeekOf19Oct(21, 9, 0, utc),
+		},
+		{
+			name:  "lunch break",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(21, 12, 30, utc),
+			want:  onWeekOf19Oct(21, 13, 0, utc),
+		},
+		{
+			name:  "open until midnight",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(24, 23, 59, utc),
+			want:  onWeekOf19Oct(24, 23, 59, utc),
+		},
+		{
+			name:  "wraps around the week after a midnight closing",
+			hours: weekly,
+			loc:   utc,
+			t:     onWeekOf19Oct(25, 0, 0, utc),
+			want:  onWeekOf19Oct(26, 9, 0, utc),
+		},
+		{
+			name:  "wraps around the week from the day after",
+			hours: mondays,
+			loc:   utc,
+			t:     onWeekOf19Oct(20, 10, 0, utc),
+			want:  onWeekOf19Oct(26, 9, 0, utc),
+		},
+		{
+			name:  "same weekday a week later",
+			hours: mondays,
+			loc:   utc,
+			t:     onWeekOf19Oct(19, 18, 0, utc),
+			want:  onWeekOf19Oct(26, 9, 0, utc),
+		},
+		{
+			name:  "hours are in the shop's timezone",
+			hours: mondays,
+			loc:   saigon,
+			t:     onWeekOf19Oct(19, 1, 0, utc),
+			want:  onWeekOf19Oct(19, 2, 0, utc),
+		},
+		{
+			name:  "day DST ends on",
+			hours: sundays,
+			loc:   newYork,
+			t:     time.Date(2026, time.November, 1, 0, 30, 0, 0, newYork),
+			want:  time.Date(2026, time.November, 1, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:  "closing on the day DST ends",
+			hours: sundays,
+			loc:   newYork,
+			t:     time.Date(2026, time.November, 1, 16, 30, 0, 0, newYork),
+			want:  time.Date(2026, time.November, 1, 16, 30, 0, 0, newYork),
+		},
+		{
+			name:  "day DST starts on",
+			hours: sundays,
+			loc:   newYork,
+			t:     time.Date(2026, time.March, 8, 0, 30, 0, 0, newYork),
+			want:  time.Date(2026, time.March, 8, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:  "after closing on the day DST starts",
+			hours: sundays,
+			loc:   newYork,
+			t:     time.Date(2026, time.March, 8, 17, 0, 0, 0, newYork),
+			want:  time.Date(2026, time.March, 15, 9, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextOpeningTime(tt.hours, tt.loc, tt.t)
+			if !ok {
+				t.Fatalf("nextOpeningTime(%v) found no opening", tt.t)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextOpeningTime(%v) = %v, want %v", tt.t, got.In(tt.loc), tt.want.In(tt.loc))
+			}
+		})
+	}
+}
+
+func TestNextOpenAt(t *testing.T) {
+	utc := time.UTC
+	weekdays := []repository.ShopOpeningHour{
+		openingHour(time.Monday, hour(9, 0), hour(17, 0)),
+		openingHour(time.Wednesday, hour(9, 0), hour(17, 0)),
+	}
+	vacation := func(from, to time.Time) repository.Shop {
+		return repository.Shop{
+			Timezone:      "UTC",
+			VacationStart: sql.NullTime{Time: from, Valid: true},
+			VacationEnd:   sql.NullTime{Time: to, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		shop  repository.Shop
+		hours []repository.ShopOpeningHour
+		t     time.Time
+		want  time.Time
+	}{
+		{
+			name:  "no vacation",
+			shop:  repository.Shop{Timezone: "UTC"},
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 7, 0, utc),
+			want:  onWeekOf19Oct(19, 9, 0, utc),
+		},
+		{
+			name: "no hours, open when the vacation ends",
+			shop: vacation(onWeekOf19Oct(19, 0, 0, utc), onWeekOf19Oct(22, 0, 0, utc)),
+			t:    onWeekOf19Oct(20, 12, 0, utc),
+			want: onWeekOf19Oct(22, 0, 0, utc),
+		},
+		{
+			name:  "vacation ends within opening hours",
+			shop:  vacation(onWeekOf19Oct(19, 8, 0, utc), onWeekOf19Oct(19, 12, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 10, 0, utc),
+			want:  onWeekOf19Oct(19, 12, 0, utc),
+		},
+		{
+			name:  "vacation ends outside opening hours",
+			shop:  vacation(onWeekOf19Oct(19, 10, 0, utc), onWeekOf19Oct(19, 20, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 11, 0, utc),
+			want:  onWeekOf19Oct(21, 9, 0, utc),
+		},
+		{
+			name:  "vacation straddles the next opening",
+			shop:  vacation(onWeekOf19Oct(19, 8, 0, utc), onWeekOf19Oct(19, 10, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 7, 0, utc),
+			want:  onWeekOf19Oct(19, 10, 0, utc),
+		},
+		{
+			name:  "vacation ends exactly at opening",
+			shop:  vacation(onWeekOf19Oct(19, 7, 0, utc), onWeekOf19Oct(19, 9, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 8, 0, utc),
+			want:  onWeekOf19Oct(19, 9, 0, utc),
+		},
+		{
+			name:  "vacation ends exactly at closing",
+			shop:  vacation(onWeekOf19Oct(19, 8, 0, utc), onWeekOf19Oct(19, 17, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 8, 0, utc),
+			want:  onWeekOf19Oct(21, 9, 0, utc),
+		},
+		{
+			name:  "vacation over a whole week wraps around",
+			shop:  vacation(onWeekOf19Oct(19, 0, 0, utc), onWeekOf19Oct(26, 12, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(20, 0, 0, utc),
+			want:  onWeekOf19Oct(26, 12, 0, utc),
+		},
+		{
+			name:  "vacation already over",
+			shop:  vacation(onWeekOf19Oct(12, 0, 0, utc), onWeekOf19Oct(13, 0, 0, utc)),
+			hours: weekdays,
+			t:     onWeekOf19Oct(19, 10, 0, utc),
+			want:  onWeekOf19Oct(19, 10, 0, utc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextOpenAt(tt.shop, tt.hours, tt.t)
+			if !ok {
+				t.Fatalf("nextOpenAt(%v) found no opening", tt.t)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextOpenAt(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOpeningHours(t *testing.T) {
+	hours := func(weekday, opensAt, closesAt int32) *pb.OpeningHours {
+		return &pb.OpeningHours{
+			Weekday:  weekday,
+			OpensAt:  opensAt,
+			ClosesAt: closesAt,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		hours []*pb.OpeningHours
+		valid bool
+	}{
+		{
+			name:  "no hours",
+			valid: true,
+		},
+		{
+			name:  "whole day",
+			hours: []*pb.OpeningHours{hours(0, 0, minutesPerDay)},
+			valid: true,
+		},
+		{
+			name:  "intervals touching",
+			hours: []*pb.OpeningHours{hours(1, hour(13, 0), hour(17, 0)), hours(1, hour(9, 0), hour(13, 0))},
+			valid: true,
+		},
+		{
+			name:  "same interval on different days",
+			hours: []*pb.OpeningHours{hours(1, hour(9, 0), hour(17, 0)), hours(2, hour(9, 0), hour(17, 0))},
+			valid: true,
+		},
+		{
+			name:  "intervals overlapping, given out of order",
+			hours: []*pb.OpeningHours{hours(3, hour(11, 0), hour(14, 0)), hours(3, hour(9, 0), hour(12, 0))},
+		},
+		{
+			name:  "weekday past saturday",
+			hours: []*pb.OpeningHours{hours(7, hour(9, 0), hour(17, 0))},
+		},
+		{
+			name:  "negative weekday",
+			hours: []*pb.OpeningHours{hours(-1, hour(9, 0), hour(17, 0))},
+		},
+		{
+			name:  "closes when it opens",
+			hours: []*pb.OpeningHours{hours(1, hour(9, 0), hour(9, 0))},
+		},
+		{
+			name:  "closes before it opens",
+			hours: []*pb.OpeningHours{hours(1, hour(17, 0), hour(9, 0))},
+		},
+		{
+			name:  "closes after midnight",
+			hours: []*pb.OpeningHours{hours(1, hour(20, 0), minutesPerDay+1)},
+		},
+		{
+			name:  "opens before the day starts",
+			hours: []*pb.OpeningHours{hours(1, -1, hour(9, 0))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOpeningHours(tt.hours)
+			if tt.valid && err != nil {
+				t.Errorf("validateOpeningHours() = %v, want nil", err)
+			}
+			if !tt.valid && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("validateOpeningHours() = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"golang.org/x/image/draw"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxAvatarSize = 2 << 20 // 2MB
+	// a small file can declare huge dimensions, and decoding allocates for
+	// every pixel
+	maxAvatarPixels = 4096 * 4096
+)
+
+// thumbnail edge lengths, in pixels
+var avatarThumbnailSizes = []int{64, 256}
+
+var avatarContentTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+var avatarExtensions = map[string]bool{
+	"jpg": true,
+	"png": true,
+}
+
+// UpdateShopAvatar receives an image as a stream: an AvatarInfo message
+// followed by the image bytes in chunks
+func (srv *ShopService) UpdateShopAvatar(stream pb.ShopService_UpdateShopAvatarServer) error {
+	ctx := stream.Context()
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "can't receive avatar info: %v", err)
+	}
+	contentType := req.GetInfo().GetContentType()
+	ext, ok := avatarContentTypes[contentType]
+	if !ok {
+		return status.Error(codes.InvalidArgument, "Ảnh đại diện phải có định dạng JPEG hoặc PNG")
+	}
+
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Unknown, "can't receive avatar chunk: %v", err)
+		}
+
+		if data.Len()+len(req.GetChunk()) > maxAvatarSize {
+			return status.Errorf(codes.InvalidArgument, "Ảnh đại diện không được vượt quá %dMB", maxAvatarSize>>20)
+		}
+		data.Write(req.GetChunk())
+	}
+
+	// don't trust the declared content type alone
+	if http.DetectContentType(data.Bytes()) != contentType {
+		return status.Error(codes.InvalidArgument, "Nội dung ảnh không khớp với định dạng")
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data.Bytes()))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "can't decode avatar: %v", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > maxAvatarPixels {
+		return status.Error(codes.InvalidArgument, "Kích thước ảnh đại diện quá lớn")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data.Bytes()))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "can't decode avatar: %v", err)
+	}
+
+	// a fresh key per upload keeps caches from serving the previous avatar
+	prefix := fmt.Sprintf("shops/%d/avatar-%d", shop.ID, time.Now().UnixNano())
+	keys := avatarKeys(prefix, ext)
+	stored, saved := 0, false
+	// don't leave the blobs of a failed upload behind
+	defer func() {
+		if !saved {
+			srv.deleteBlobs(ctx, keys[:stored])
+		}
+	}()
+
+	avatarURL, err := srv.avatarStore.Put(ctx, keys[0], contentType, data.Bytes())
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't store avatar: %v", err)
+	}
+	stored++
+
+	resp := &pb.UpdateShopAvatarResponse{
+		Avatar: avatarURL,
+	}
+	for i, size := range avatarThumbnailSizes {
+		thumbnail, err := encodeThumbnail(img, size, contentType)
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't create thumbnail: %v", err)
+		}
+
+		url, err := srv.avatarStore.Put(ctx, keys[i+1], contentType, thumbnail)
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't store thumbnail: %v", err)
+		}
+		stored++
+		resp.Thumbnails = append(resp.Thumbnails, url)
+	}
+
+	// only replace the avatar read above, so a concurrent change isn't lost
+	// and its blobs aren't deleted from under it
+	updated, err := srv.shopStore.UpdateShopAvatar(ctx, repository.UpdateShopAvatarParams{
+		Avatar: sql.NullString{
+			String: avatarURL,
+			Valid:  true,
+		},
+		SellerID:  me.ID,
+		OldAvatar: shop.Avatar,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't update avatar: %v", err)
+	}
+	if updated == 0 {
+		return status.Error(codes.Aborted, "Ảnh đại diện vừa được thay đổi, vui lòng thử lại")
+	}
+	saved = true
+
+	// the previous avatar is no longer referenced
+	srv.deleteUploadedAvatar(ctx, shop.ID, shop.Avatar.String)
+
+	return stream.SendAndClose(resp)
+}
+
+// avatarKeys returns the blob keys of an avatar uploaded under prefix: the
+// image itself, then its thumbnails
+func avatarKeys(prefix string, ext string) []string {
+	keys := []string{prefix + "." + ext}
+	for _, size := range avatarThumbnailSizes {
+		keys = append(keys, fmt.Sprintf("%s-%d.%s", prefix, size, ext))
+	}
+
+	return keys
+}
+
+// uploadedAvatarKey returns the blob key of an avatar url, when the avatar
+// was uploaded through UpdateShopAvatar rather than set to an outside url
+func uploadedAvatarKey(shopID int64, avatarURL string) (string, bool) {
+	i := strings.Index(avatarURL, fmt.Sprintf("shops/%d/avatar-", shopID))
+	if i < 0 {
+		return "", false
+	}
+
+	key := avatarURL[i:]
+	if !avatarExtensions[strings.TrimPrefix(path.Ext(key), ".")] {
+		return "", false
+	}
+	return key, true
+}
+
+// deleteUploadedAvatar removes the blobs of an avatar no longer referenced,
+// when it was uploaded through UpdateShopAvatar
+func (srv *ShopService) deleteUploadedAvatar(ctx context.Context, shopID int64, avatarURL string) {
+	key, ok := uploadedAvatarKey(shopID, avatarURL)
+	if !ok {
+		return
+	}
+
+	prefix := strings.TrimSuffix(key, path.Ext(key))
+	srv.deleteBlobs(ctx, avatarKeys(prefix, strings.TrimPrefix(path.Ext(key), ".")))
+}
+
+// deleteBlobs removes blobs no longer referenced, failures only leave
+// garbage behind so they are logged
+func (srv *ShopService) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := srv.avatarStore.Delete(ctx, key); err != nil {
+			log.Printf("can't delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// encodeThumbnail scales img to fit in a size x size square, keeping its
+// aspect ratio, and encodes it in the original format
+func encodeThumbnail(img image.Image, size int, contentType string) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := size, size
+	if bounds.Dx() > bounds.Dy() {
+		height = bounds.Dy() * size / bounds.Dx()
+	} else {
+		width = bounds.Dx() * size / bounds.Dy()
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	}
+
+	return buf.Bytes(), err
+}
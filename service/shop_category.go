@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateCategoryIDs checks ids against the categories known to
+// product-service and drops duplicates
+func (srv *ShopService) validateCategoryIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	known, err := srv.knownCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	categoryIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return nil, status.Errorf(codes.InvalidArgument, "Danh mục %d không tồn tại", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			categoryIDs = append(categoryIDs, id)
+		}
+	}
+
+	return categoryIDs, nil
+}
+
+// knownCategories returns the ids of the categories known to product-service
+func (srv *ShopService) knownCategories(ctx context.Context) (map[int64]bool, error) {
+	resp, err := srv.productClient.GetListCategory(ctx, _empty)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]bool, len(resp.GetListCategory()))
+	for _, category := range resp.GetListCategory() {
+		known[category.GetCategoryId()] = true
+	}
+
+	return known, nil
+}
+
+// ListShopsByCategory returns the shops selling in a category, newest first
+func (srv *ShopService) ListShopsByCategory(ctx context.Context, req *pb.ListShopsByCategoryRequest) (*pb.ListShopsByCategoryResponse, error) {
+	limit := pageLimit(req.GetLimit())
+	shops, err := srv.shopStore.ListShopsByCategory(ctx, repository.ListShopsByCategoryParams{
+		CategoryID: req.GetCategoryId(),
+		Cursor:     req.GetCursor(),
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list shops: %v", err)
+	}
+
+	resp := &pb.ListShopsByCategoryResponse{
+		Shops: make([]*pb.ShopSummary, 0, len(shops)),
+	}
+	for _, shop := range shops {
+		resp.Shops = append(resp.Shops, shopSummary(shop))
+	}
+	if len(shops) == int(limit) {
+		resp.NextCursor = shops[len(shops)-1].ID
+	}
+
+	return resp, nil
+}
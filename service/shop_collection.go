@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxCollectionNameLength = 64
+	maxShopCollections      = 50
+	maxCollectionItems      = 200
+)
+
+// CreateCollection adds a collection to the caller's shop
+func (srv *ShopService) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.ShopCollection, error) {
+	name, err := validateCollection(req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := srv.shopStore.CountShopCollections(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't count collections: %v", err)
+	}
+	if count >= maxShopCollections {
+		return nil, status.Errorf(codes.FailedPrecondition, "Mỗi cửa hàng chỉ được tạo tối đa %d bộ sưu tập", maxShopCollections)
+	}
+
+	collection, err := srv.shopStore.CreateShopCollection(ctx, repository.CreateShopCollectionParams{
+		ShopID:      shop.ID,
+		Name:        name,
+		Description: req.GetDescription(),
+		Position:    req.GetPosition(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't create collection: %v", err)
+	}
+
+	return shopCollection(collection, 0), nil
+}
+
+// UpdateCollection renames or moves a collection of the caller's shop
+func (srv *ShopService) UpdateCollection(ctx context.Context, req *pb.UpdateCollectionRequest) (*pb.ShopCollection, error) {
+	name, err := validateCollection(req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := srv.shopStore.UpdateShopCollection(ctx, repository.UpdateShopCollectionParams{
+		ID:          req.GetCollectionId(),
+		ShopID:      shop.ID,
+		Name:        name,
+		Description: req.GetDescription(),
+		Position:    req.GetPosition(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Bộ sưu tập không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't update collection: %v", err)
+	}
+
+	productIDs, err := srv.shopStore.ListShopCollectionItems(ctx, collection.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list collection items: %v", err)
+	}
+
+	return shopCollection(collection, int64(len(productIDs))), nil
+}
+
+// DeleteCollection removes a collection of the caller's shop, the products
+// in it are left alone
+func (srv *ShopService) DeleteCollection(ctx context.Context, req *pb.CollectionRequest) (*pb.GeneralResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := srv.shopStore.DeleteShopCollection(ctx, repository.DeleteShopCollectionParams{
+		ID:     req.GetCollectionId(),
+		ShopID: shop.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't delete collection: %v", err)
+	}
+	if deleted == 0 {
+		return nil, status.Error(codes.NotFound, "Bộ sưu tập không tồn tại")
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Xóa bộ sưu tập thành công",
+	}, nil
+}
+
+// SetCollectionItems replaces the products of a collection, in the given
+// order
+func (srv *ShopService) SetCollectionItems(ctx context.Context, req *pb.SetCollectionItemsRequest) (*pb.ShopCollection, error) {
+	if len(req.GetProductIds()) > maxCollectionItems {
+		return nil, status.Errorf(codes.InvalidArgument, "Mỗi bộ sưu tập chỉ được có tối đa %d sản phẩm", maxCollectionItems)
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(req.GetProductIds()))
+	productIDs := make([]int64, 0, len(req.GetProductIds()))
+	for _, id := range req.GetProductIds() {
+		if !seen[id] {
+			seen[id] = true
+			productIDs = append(productIDs, id)
+		}
+	}
+
+	products, err := srv.productsByID(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.Product, 0, len(productIDs))
+	for _, id := range productIDs {
+		product, ok := products[id]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "Sản phẩm %d không tồn tại", id)
+		}
+		if product.GetSupplierId() != me.ID {
+			return nil, status.Errorf(codes.PermissionDenied, "Sản phẩm %d không thuộc cửa hàng của bạn", id)
+		}
+		items = append(items, product)
+	}
+
+	var collection repository.ShopCollection
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		collection, err = q.GetShopCollection(ctx, req.GetCollectionId())
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && collection.ShopID != shop.ID) {
+			return status.Error(codes.NotFound, "Bộ sưu tập không tồn tại")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't get collection: %v", err)
+		}
+
+		if err := q.ClearShopCollectionItems(ctx, collection.ID); err != nil {
+			return status.Errorf(codes.Internal, "can't clear collection: %v", err)
+		}
+		for i, id := range productIDs {
+			err := q.AddShopCollectionItem(ctx, repository.AddShopCollectionItemParams{
+				CollectionID: collection.ID,
+				ProductID:    id,
+				Position:     int32(i),
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "can't add product to collection: %v", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := shopCollection(collection, int64(len(items)))
+	resp.Items = items
+
+	return resp, nil
+}
+
+// ListShopCollections returns the collections of a shop without their items
+func (srv *ShopService) ListShopCollections(ctx context.Context, req *pb.ListShopCollectionsRequest) (*pb.ListShopCollectionsResponse, error) {
+	if _, err := srv.visibleShop(ctx, req.GetShopId()); err != nil {
+		return nil, err
+	}
+
+	collections, err := srv.shopStore.ListShopCollections(ctx, req.GetShopId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list collections: %v", err)
+	}
+
+	resp := &pb.ListShopCollectionsResponse{
+		Collections: make([]*pb.ShopCollection, 0, len(collections)),
+	}
+	for _, row := range collections {
+		resp.Collections = append(resp.Collections, shopCollection(repository.ShopCollection{
+			ID:          row.ID,
+			ShopID:      row.ShopID,
+			Name:        row.Name,
+			Description: row.Description,
+			Position:    row.Position,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		}, row.ItemCount))
+	}
+
+	return resp, nil
+}
+
+// GetShopCollection returns a collection with its products. Products
+// product-service no longer knows about are dropped from the collection.
+func (srv *ShopService) GetShopCollection(ctx context.Context, req *pb.CollectionRequest) (*pb.ShopCollection, error) {
+	collection, err := srv.shopStore.GetShopCollection(ctx, req.GetCollectionId())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Bộ sưu tập không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get collection: %v", err)
+	}
+	if _, err := srv.visibleShop(ctx, collection.ShopID); err != nil {
+		return nil, err
+	}
+
+	productIDs, err := srv.shopStore.ListShopCollectionItems(ctx, collection.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list collection items: %v", err)
+	}
+	products, err := srv.productsByID(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := shopCollection(collection, 0)
+	var missing []int64
+	for _, id := range productIDs {
+		product, ok := products[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		resp.Items = append(resp.Items, product)
+	}
+	resp.ItemCount = int64(len(resp.Items))
+
+	if len(missing) > 0 {
+		err := srv.shopStore.RemoveShopCollectionItems(ctx, repository.RemoveShopCollectionItemsParams{
+			CollectionID: collection.ID,
+			ProductIds:   missing,
+		})
+		if err != nil {
+			log.Printf("can't prune collection %d: %v", collection.ID, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// productsByID fetches products by id in one round trip, products
+// product-service doesn't know about are left out
+func (srv *ShopService) productsByID(ctx context.Context, ids []int64) (map[int64]*pb.Product, error) {
+	products := make(map[int64]*pb.Product, len(ids))
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	resp, err := srv.productClient.GetListProductByIDs(ctx, &pb.GetListProductByIDsRequest{
+		ListId: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range resp.GetListProduct() {
+		products[product.GetProductId()] = product
+	}
+
+	return products, nil
+}
+
+// visibleShop returns a shop the public may see
+func (srv *ShopService) visibleShop(ctx context.Context, shopID int64) (repository.Shop, error) {
+	shop, err := srv.shopStore.GetShopByID(ctx, shopID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !shopVisible(shop)) {
+		return shop, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
+	if err != nil {
+		return shop, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	return shop, nil
+}
+
+func validateCollection(name string, description string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "Vui lòng điền tên bộ sưu tập")
+	}
+	if utf8.RuneCountInString(name) > maxCollectionNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "Tên bộ sưu tập không được dài quá %d kí tự", maxCollectionNameLength)
+	}
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return "", status.Errorf(codes.InvalidArgument, "Mô tả không được dài quá %d kí tự", maxDescriptionLength)
+	}
+
+	return name, nil
+}
+
+func shopCollection(collection repository.ShopCollection, itemCount int64) *pb.ShopCollection {
+	return &pb.ShopCollection{
+		Id:          collection.ID,
+		ShopId:      collection.ShopID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Position:    collection.Position,
+		ItemCount:   itemCount,
+	}
+}
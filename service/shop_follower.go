@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// pageLimit clamps a client supplied page size into (0, maxPageLimit]
+func pageLimit(limit int32) int32 {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
+
+// FollowShop ...
+func (srv *ShopService) FollowShop(ctx context.Context, req *pb.FollowShopRequest) (*pb.GeneralResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.shopStore.FollowShop(ctx, repository.FollowShopParams{
+		ShopID: req.GetShopId(),
+		UserID: me.ID,
+	})
+	if isForeignKeyViolation(err) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't follow shop: %v", err)
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Theo dõi cửa hàng thành công",
+	}, nil
+}
+
+// UnfollowShop ...
+func (srv *ShopService) UnfollowShop(ctx context.Context, req *pb.UnfollowShopRequest) (*pb.GeneralResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.shopStore.UnfollowShop(ctx, repository.UnfollowShopParams{
+		ShopID: req.GetShopId(),
+		UserID: me.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't unfollow shop: %v", err)
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Bỏ theo dõi cửa hàng thành công",
+	}, nil
+}
+
+// ListFollowers returns the followers of a shop, newest first. Pass the
+// returned next_cursor back to fetch the following page; 0 means no more.
+func (srv *ShopService) ListFollowers(ctx context.Context, req *pb.ListFollowersRequest) (*pb.ListFollowersResponse, error) {
+	limit := pageLimit(req.GetLimit())
+	rows, err := srv.shopStore.ListShopFollowers(ctx, repository.ListShopFollowersParams{
+		ShopID:   req.GetShopId(),
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list followers: %v", err)
+	}
+
+	resp := &pb.ListFollowersResponse{
+		Followers: make([]*pb.Follower, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Followers = append(resp.Followers, &pb.Follower{
+			UserId:     row.UserID,
+			FollowedAt: timestamppb.New(row.CreatedAt),
+		})
+	}
+	if len(rows) == int(limit) {
+		resp.NextCursor = rows[len(rows)-1].ID
+	}
+
+	return resp, nil
+}
+
+// ListFollowedShops returns the shops followed by the caller, newest first.
+func (srv *ShopService) ListFollowedShops(ctx context.Context, req *pb.ListFollowedShopsRequest) (*pb.ListFollowedShopsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(req.GetLimit())
+	rows, err := srv.shopStore.ListFollowedShops(ctx, repository.ListFollowedShopsParams{
+		UserID:   me.ID,
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list followed shops: %v", err)
+	}
+
+	resp := &pb.ListFollowedShopsResponse{
+		Shops: make([]*pb.FollowedShop, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Shops = append(resp.Shops, &pb.FollowedShop{
+			ShopId:     row.ID,
+			Name:       row.Name,
+			Avatar:     row.Avatar.String,
+			FollowedAt: timestamppb.New(row.FollowedAt),
+		})
+	}
+	if len(rows) == int(limit) {
+		resp.NextCursor = rows[len(rows)-1].FollowID
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shop status values, stored by name in shop.status
+var (
+	shopActive        = pb.ShopStatus_active.String()
+	shopSuspended     = pb.ShopStatus_suspended.String()
+	shopClosed        = pb.ShopStatus_closed.String()
+	shopPendingReview = pb.ShopStatus_pending_review.String()
+)
+
+func shopStatus(s string) pb.ShopStatus {
+	return pb.ShopStatus(pb.ShopStatus_value[s])
+}
+
+// shopVisible reports whether the public may see the shop
+func shopVisible(shop repository.Shop) bool {
+	return shop.Status != shopSuspended && shop.Status != shopClosed
+}
+
+// checkShopActive rejects changes to the catalog of a suspended or closed
+// shop. Sellers without a shop, e.g. admins, are not restricted.
+func (srv *ShopService) checkShopActive(ctx context.Context, sellerID int64) error {
+	shop, err := srv.shopStore.GetShopBySellerID(ctx, sellerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	switch shop.Status {
+	case shopSuspended:
+		return status.Error(codes.FailedPrecondition, "Cửa hàng của bạn đang bị tạm khóa")
+	case shopClosed:
+		return status.Error(codes.FailedPrecondition, "Cửa hàng của bạn đã bị đóng")
+	}
+
+	return nil
+}
+
+// SuspendShop ...
+func (srv *ShopService) SuspendShop(ctx context.Context, req *pb.ModerateShopRequest) (*pb.GeneralResponse, error) {
+	err := srv.moderateShop(ctx, req, shopSuspended, shopActive, shopPendingReview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Tạm khóa cửa hàng thành công",
+	}, nil
+}
+
+// ReinstateShop ...
+func (srv *ShopService) ReinstateShop(ctx context.Context, req *pb.ModerateShopRequest) (*pb.GeneralResponse, error) {
+	err := srv.moderateShop(ctx, req, shopActive, shopSuspended, shopPendingReview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Mở khóa cửa hàng thành công",
+	}, nil
+}
+
+// CloseShop ...
+func (srv *ShopService) CloseShop(ctx context.Context, req *pb.ModerateShopRequest) (*pb.GeneralResponse, error) {
+	err := srv.moderateShop(ctx, req, shopClosed, shopActive, shopSuspended, shopPendingReview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Đóng cửa hàng thành công",
+	}, nil
+}
+
+// moderateShop moves a shop to status `to` if it is currently in one of
+// `from`, recording the reason and the acting admin
+func (srv *ShopService) moderateShop(ctx context.Context, req *pb.ModerateShopRequest, to string, from ...string) error {
+	reason := strings.TrimSpace(req.GetReason())
+	if reason == "" {
+		return status.Error(codes.InvalidArgument, "Vui lòng nhập lý do")
+	}
+
+	admin, err := auth.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		shop, err := q.GetShopForUpdate(ctx, req.GetShopId())
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't get shop: %v", err)
+		}
+		if !containsString(from, shop.Status) {
+			return status.Errorf(codes.FailedPrecondition, "can't move shop from %s to %s", shop.Status, to)
+		}
+
+		err = q.UpdateShopStatus(ctx, repository.UpdateShopStatusParams{
+			ID:     shop.ID,
+			Status: to,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't update shop status: %v", err)
+		}
+
+		err = q.CreateShopModerationLog(ctx, repository.CreateShopModerationLogParams{
+			ShopID:     shop.ID,
+			AdminID:    admin.ID,
+			FromStatus: shop.Status,
+			ToStatus:   to,
+			Reason:     reason,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't record moderation: %v", err)
+		}
+
+		// reinstating clears the reports that led to the review, so only
+		// new reports count toward the next one
+		if to == shopActive {
+			if err := q.ResolveShopReports(ctx, shop.ID); err != nil {
+				return status.Errorf(codes.Internal, "can't resolve reports: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListShopsForReview returns the shops waiting for an admin, newest first
+func (srv *ShopService) ListShopsForReview(ctx context.Context, req *pb.ListShopsForReviewRequest) (*pb.ListShopsForReviewResponse, error) {
+	limit := pageLimit(req.GetLimit())
+	shops, err := srv.shopStore.ListShopsByStatus(ctx, repository.ListShopsByStatusParams{
+		Status:   shopPendingReview,
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list shops: %v", err)
+	}
+
+	resp := &pb.ListShopsForReviewResponse{
+		Shops: make([]*pb.ShopSummary, 0, len(shops)),
+	}
+	for _, shop := range shops {
+		resp.Shops = append(resp.Shops, shopSummary(shop))
+	}
+	if len(shops) == int(limit) {
+		resp.NextCursor = shops[len(shops)-1].ID
+	}
+
+	return resp, nil
+}
+
+func shopSummary(shop repository.Shop) *pb.ShopSummary {
+	return &pb.ShopSummary{
+		ShopId:   shop.ID,
+		SellerId: shop.SellerID,
+		Name:     shop.Name,
+		Avatar:   shop.Avatar.String,
+		Status:   shopStatus(shop.Status),
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ListMyProducts lists the caller's own catalog. Unfiltered pages come
+// straight from product-service, filtered ones are cut from the cached
+// catalog and may be up to Config.CatalogCacheTTL old.
+func (srv *ShopService) ListMyProducts(ctx context.Context, req *pb.ListMyProductsRequest) (*pb.ListProductsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &pb.GetProductBySupplierRequest{
+		SupplierId:  me.ID,
+		Limit:       req.GetLimit(),
+		Offset:      req.GetOffset(),
+		ByTime:      req.GetByTime(),
+		ByPriceInc:  req.GetByPriceInc(),
+		ByPriceDesc: req.GetByPriceDesc(),
+	}
+	if proto.Size(req.GetFilter()) == 0 {
+		return srv.productPage(ctx, query)
+	}
+
+	return srv.listProducts(ctx, query, req.GetFilter(), srv.cachedSupplierProducts)
+}
+
+// productPage fetches one page of the supplier's catalog from product-service,
+// the total is counted on the cached catalog
+func (srv *ShopService) productPage(ctx context.Context, query *pb.GetProductBySupplierRequest) (*pb.ListProductsResponse, error) {
+	if err := validateProductQuery(query, nil); err != nil {
+		return nil, err
+	}
+
+	page, err := srv.productClient.GetProductBySupplier(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId:  query.GetSupplierId(),
+		Limit:       pageLimit(query.GetLimit()),
+		Offset:      query.GetOffset(),
+		ByTime:      query.GetByTime(),
+		ByPriceInc:  query.GetByPriceInc(),
+		ByPriceDesc: query.GetByPriceDesc(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// any order counts the same, share the catalog the shop profile uses
+	catalog, err := srv.cachedSupplierProducts(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId: query.GetSupplierId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListProductsResponse{
+		Products: page.GetListProduct(),
+		Total:    int64(len(catalog)),
+	}, nil
+}
+
+// ListShopProducts lists the catalog of a shop for storefront visitors
+func (srv *ShopService) ListShopProducts(ctx context.Context, req *pb.ListShopProductsRequest) (*pb.ListProductsResponse, error) {
+	shop, err := srv.shopStore.GetShopByID(ctx, req.GetShopId())
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !shopVisible(shop)) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	return srv.listProducts(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId:  shop.SellerID,
+		Limit:       req.GetLimit(),
+		Offset:      req.GetOffset(),
+		ByTime:      req.GetByTime(),
+		ByPriceInc:  req.GetByPriceInc(),
+		ByPriceDesc: req.GetByPriceDesc(),
+	}, req.GetFilter(), srv.cachedSupplierProducts)
+}
+
+// listProducts filters the supplier's catalog, as fetched by fetch, and cuts
+// the requested page. product-service can't filter, so the whole catalog is
+// fetched and the page is cut here, otherwise filtered pages would come back
+// short.
+func (srv *ShopService) listProducts(ctx context.Context, query *pb.GetProductBySupplierRequest, filter *pb.ProductFilter, fetch func(context.Context, *pb.GetProductBySupplierRequest) ([]*pb.Product, error)) (*pb.ListProductsResponse, error) {
+	if err := validateProductQuery(query, filter); err != nil {
+		return nil, err
+	}
+
+	products, err := fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the catalog may be shared with the cache, filter into a new slice
+	matched := make([]*pb.Product, 0, len(products))
+	for _, product := range products {
+		if matchProductFilter(product, filter) {
+			matched = append(matched, product)
+		}
+	}
+
+	resp := &pb.ListProductsResponse{
+		Total: int64(len(matched)),
+	}
+	offset := int(query.GetOffset())
+	if offset < len(matched) {
+		end := offset + int(pageLimit(query.GetLimit()))
+		if end > len(matched) {
+			end = len(matched)
+		}
+		resp.Products = matched[offset:end]
+	}
+
+	return resp, nil
+}
+
+func validateProductQuery(query *pb.GetProductBySupplierRequest, filter *pb.ProductFilter) error {
+	sorts := 0
+	for _, flag := range []bool{query.GetByTime(), query.GetByPriceInc(), query.GetByPriceDesc()} {
+		if flag {
+			sorts++
+		}
+	}
+	if sorts > 1 {
+		return status.Error(codes.InvalidArgument, "only one of byTime, byPriceInc and byPriceDesc can be set")
+	}
+	if query.GetOffset() < 0 {
+		return status.Error(codes.InvalidArgument, "offset can't be negative")
+	}
+	if filter.GetMinPrice() < 0 || filter.GetMaxPrice() < 0 || filter.GetLowStockBelow() < 0 {
+		return status.Error(codes.InvalidArgument, "price and stock filters can't be negative")
+	}
+	if filter.GetMaxPrice() > 0 && filter.GetMinPrice() > filter.GetMaxPrice() {
+		return status.Error(codes.InvalidArgument, "min_price can't be greater than max_price")
+	}
+
+	return nil
+}
+
+// matchProductFilter treats zero values as "no filter"
+func matchProductFilter(product *pb.Product, filter *pb.ProductFilter) bool {
+	if filter.GetBrand() != "" && !strings.EqualFold(product.GetBrand(), filter.GetBrand()) {
+		return false
+	}
+	if filter.GetCategoryId() != 0 && product.GetCategoryId() != filter.GetCategoryId() {
+		return false
+	}
+	if product.GetPrice() < filter.GetMinPrice() {
+		return false
+	}
+	if filter.GetMaxPrice() > 0 && product.GetPrice() > filter.GetMaxPrice() {
+		return false
+	}
+	if filter.GetLowStockBelow() > 0 && int64(product.GetInventory()) >= filter.GetLowStockBelow() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxReportDetailLength = 1000
+	reportForwardBatch    = 100
+	// a pending report younger than this may still be forwarded by the
+	// request that flagged the shop
+	reportForwardGrace = time.Minute
+)
+
+// ReportShop records a report against a shop. Once enough distinct users have
+// open reports the shop is sent to review and its seller is reported to
+// user-service.
+func (srv *ShopService) ReportShop(ctx context.Context, req *pb.ReportShopRequest) (*pb.GeneralResponse, error) {
+	if _, ok := pb.ShopReportReason_name[int32(req.GetReason())]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "Lý do báo cáo không hợp lệ")
+	}
+	if req.GetReason() == pb.ShopReportReason_other && req.GetDetail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Vui lòng mô tả lý do báo cáo")
+	}
+	if utf8.RuneCountInString(req.GetDetail()) > maxReportDetailLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Nội dung báo cáo không được dài quá %d kí tự", maxReportDetailLength)
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shop, err := srv.shopStore.GetShopByID(ctx, req.GetShopId())
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !shopVisible(shop)) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+	if shop.SellerID == me.ID {
+		return nil, status.Error(codes.InvalidArgument, "Bạn không thể báo cáo cửa hàng của mình")
+	}
+
+	var flagged bool
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		// the shop row lock serializes concurrent reports, each would
+		// otherwise count the reporters without the other's report
+		if _, err := q.GetShopForUpdate(ctx, shop.ID); err != nil {
+			return err
+		}
+
+		n, err := q.CreateShopReport(ctx, repository.CreateShopReportParams{
+			ShopID:     shop.ID,
+			ReporterID: me.ID,
+			Reason:     req.GetReason().String(),
+			Detail:     req.GetDetail(),
+		})
+		if err != nil || n == 0 {
+			// a repeat report from the same user doesn't count again
+			return err
+		}
+
+		reporters, err := q.CountOpenShopReporters(ctx, shop.ID)
+		if err != nil {
+			return err
+		}
+		if reporters < srv.config.ReportThreshold {
+			return nil
+		}
+
+		n, err = q.FlagShopForReview(ctx, shop.ID)
+		if err != nil || n == 0 {
+			return err
+		}
+		flagged = true
+
+		// ForwardShopReports retries the report if it can't be sent below
+		return q.MarkShopReportForwardPending(ctx, shop.ID)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't report shop: %v", err)
+	}
+
+	if flagged {
+		if err := srv.forwardShopReport(ctx, shop); err != nil {
+			log.Printf("can't forward report of shop %d to user service, will retry: %v", shop.ID, err)
+		}
+	}
+
+	return &pb.GeneralResponse{
+		Message: "Cảm ơn bạn đã báo cáo, chúng tôi sẽ xem xét cửa hàng này",
+	}, nil
+}
+
+// ForwardShopReports periodically sends user-service the reports of flagged
+// shops that couldn't be forwarded when they were flagged
+func (srv *ShopService) ForwardShopReports(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		srv.forwardPendingShopReports(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *ShopService) forwardPendingShopReports(ctx context.Context) {
+	shops, err := srv.shopStore.ListPendingShopReportForwards(ctx, repository.ListPendingShopReportForwardsParams{
+		PendingBefore: sql.NullTime{
+			Time:  time.Now().Add(-reportForwardGrace),
+			Valid: true,
+		},
+		RowLimit: reportForwardBatch,
+	})
+	if err != nil {
+		log.Println("can't list pending shop reports: ", err)
+		return
+	}
+
+	for _, shop := range shops {
+		if err := srv.forwardShopReport(ctx, shop); err != nil {
+			log.Printf("can't forward report of shop %d to user service: %v", shop.ID, err)
+		}
+	}
+}
+
+// forwardShopReport reports the seller of a flagged shop to user-service and
+// clears the pending marker of the shop
+func (srv *ShopService) forwardShopReport(ctx context.Context, shop repository.Shop) error {
+	err := retryTransient(ctx, func() error {
+		_, err := srv.userClient.SupplierReport(ctx, &pb.SupplierReportRequest{
+			SupplierId: shop.SellerID,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return srv.shopStore.ClearShopReportForwardPending(ctx, shop.ID)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxReviewCommentLength = 1000
+	maxReviewReplyLength   = 1000
+)
+
+// RateShop reviews a shop, rating it again replaces the caller's earlier
+// review
+func (srv *ShopService) RateShop(ctx context.Context, req *pb.RateShopRequest) (*pb.ShopReview, error) {
+	if req.GetRating() < 1 || req.GetRating() > 5 {
+		return nil, status.Error(codes.InvalidArgument, "Số sao đánh giá phải từ 1 đến 5")
+	}
+	if utf8.RuneCountInString(req.GetComment()) > maxReviewCommentLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Nội dung đánh giá không được dài quá %d kí tự", maxReviewCommentLength)
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.visibleShop(ctx, req.GetShopId())
+	if err != nil {
+		return nil, err
+	}
+	if shop.SellerID == me.ID {
+		return nil, status.Error(codes.PermissionDenied, "Bạn không thể đánh giá cửa hàng của mình")
+	}
+
+	var review repository.ShopReview
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		// the shop row lock serializes the first review of a customer, which
+		// has no review row to lock yet
+		if _, err := q.GetShopForUpdate(ctx, shop.ID); err != nil {
+			return status.Errorf(codes.Internal, "can't lock shop: %v", err)
+		}
+
+		rating := repository.AddShopRatingParams{ID: shop.ID}
+		old, err := q.GetShopReviewForUpdate(ctx, repository.GetShopReviewForUpdateParams{
+			ShopID: shop.ID,
+			UserID: me.ID,
+		})
+		switch {
+		case err == nil:
+			review, err = q.UpdateShopReview(ctx, repository.UpdateShopReviewParams{
+				ID:      old.ID,
+				Rating:  int16(req.GetRating()),
+				Comment: req.GetComment(),
+			})
+			rating.RatingDelta = int64(req.GetRating()) - int64(old.Rating)
+		case errors.Is(err, sql.ErrNoRows):
+			review, err = q.CreateShopReview(ctx, repository.CreateShopReviewParams{
+				ShopID:  shop.ID,
+				UserID:  me.ID,
+				Rating:  int16(req.GetRating()),
+				Comment: req.GetComment(),
+			})
+			rating.CountDelta = 1
+			rating.RatingDelta = int64(req.GetRating())
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't save review: %v", err)
+		}
+
+		if err := q.AddShopRating(ctx, rating); err != nil {
+			return status.Errorf(codes.Internal, "can't update shop rating: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shopReview(review), nil
+}
+
+// ListShopReviews returns the reviews of a shop, newest first
+func (srv *ShopService) ListShopReviews(ctx context.Context, req *pb.ListShopReviewsRequest) (*pb.ListShopReviewsResponse, error) {
+	if _, err := srv.visibleShop(ctx, req.GetShopId()); err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(req.GetLimit())
+	reviews, err := srv.shopStore.ListShopReviews(ctx, repository.ListShopReviewsParams{
+		ShopID:   req.GetShopId(),
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list reviews: %v", err)
+	}
+
+	resp := &pb.ListShopReviewsResponse{
+		Reviews: make([]*pb.ShopReview, 0, len(reviews)),
+	}
+	for _, review := range reviews {
+		resp.Reviews = append(resp.Reviews, shopReview(review))
+	}
+	if len(reviews) == int(limit) {
+		resp.NextCursor = reviews[len(reviews)-1].ID
+	}
+
+	return resp, nil
+}
+
+// ReplyShopReview answers a review of the caller's shop, replying again
+// replaces the earlier reply
+func (srv *ShopService) ReplyShopReview(ctx context.Context, req *pb.ReplyShopReviewRequest) (*pb.ShopReview, error) {
+	reply := strings.TrimSpace(req.GetReply())
+	if reply == "" {
+		return nil, status.Error(codes.InvalidArgument, "Vui lòng điền nội dung phản hồi")
+	}
+	if utf8.RuneCountInString(reply) > maxReviewReplyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Nội dung phản hồi không được dài quá %d kí tự", maxReviewReplyLength)
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := srv.shopStore.ReplyShopReview(ctx, repository.ReplyShopReviewParams{
+		ID:     req.GetReviewId(),
+		ShopID: shop.ID,
+		Reply:  reply,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Đánh giá không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't reply to review: %v", err)
+	}
+
+	return shopReview(review), nil
+}
+
+func shopReview(review repository.ShopReview) *pb.ShopReview {
+	resp := &pb.ShopReview{
+		Id:        review.ID,
+		ShopId:    review.ShopID,
+		UserId:    review.UserID,
+		Rating:    int32(review.Rating),
+		Comment:   review.Comment,
+		Reply:     review.Reply,
+		CreatedAt: timestamppb.New(review.CreatedAt),
+		UpdatedAt: timestamppb.New(review.UpdatedAt),
+	}
+	if review.RepliedAt.Valid {
+		resp.RepliedAt = timestamppb.New(review.RepliedAt.Time)
+	}
+
+	return resp
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 	"log"
-	"strconv"
+	"strings"
+	"time"
 
+	"github.com/e-commerce-microservices/shop-service/auth"
 	"github.com/e-commerce-microservices/shop-service/pb"
 	"github.com/e-commerce-microservices/shop-service/repository"
+	"github.com/e-commerce-microservices/shop-service/storage"
 	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type shopRepository interface {
@@ -22,23 +25,41 @@ type shopRepository interface {
 	GetShopByID(ctx context.Context, id int64) (repository.Shop, error)
 }
 
+// Config tunes the behaviour of ShopService
+type Config struct {
+	// distinct reporters needed to send a shop to review
+	ReportThreshold int64
+	// how long GetShopStats serves cached stats
+	StatsCacheTTL time.Duration
+	// how long public pages serve a cached catalog
+	CatalogCacheTTL time.Duration
+}
+
 // ShopService ...
 type ShopService struct {
-	shopStore     *repository.Queries
+	shopStore     *repository.Store
 	authClient    pb.AuthServiceClient
 	userClient    pb.UserServiceClient
 	productClient pb.ProductServiceClient
+	avatarStore   storage.BlobStore
+	config        Config
+	stats         *statsCache
+	catalog       *catalogCache
 
 	pb.UnimplementedShopServiceServer
 }
 
 // NewShopService ...
-func NewShopService(shopStore *repository.Queries, authClient pb.AuthServiceClient, userClient pb.UserServiceClient, productClient pb.ProductServiceClient) *ShopService {
+func NewShopService(shopStore *repository.Store, authClient pb.AuthServiceClient, userClient pb.UserServiceClient, productClient pb.ProductServiceClient, avatarStore storage.BlobStore, config Config) *ShopService {
 	service := &ShopService{
 		shopStore:     shopStore,
 		authClient:    authClient,
 		userClient:    userClient,
 		productClient: productClient,
+		avatarStore:   avatarStore,
+		config:        config,
+		stats:         newStatsCache(config.StatsCacheTTL),
+		catalog:       newCatalogCache(config.CatalogCacheTTL),
 	}
 
 	return service
@@ -46,64 +67,168 @@ func NewShopService(shopStore *repository.Queries, authClient pb.AuthServiceClie
 
 // UpdateShopName ...
 func (srv *ShopService) UpdateShopName(ctx context.Context, req *pb.UpdateShopNameRequest) (*pb.GetShopResponse, error) {
-	if len(req.GetName()) == 0 {
-		return nil, errors.New("Vui lòng điền tên cửa hàng")
+	return srv.UpdateShopProfile(ctx, &pb.UpdateShopProfileRequest{
+		Profile: &pb.ShopProfile{
+			Name: req.GetName(),
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{profileName},
+		},
+	})
+}
+
+// UpdateShopProfile updates the fields named in the update mask and returns
+// the updated profile
+func (srv *ShopService) UpdateShopProfile(ctx context.Context, req *pb.UpdateShopProfileRequest) (*pb.GetShopResponse, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
 	}
-	// auth
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "can't parse context")
+	if err := validateShopProfile(req.GetProfile(), paths); err != nil {
+		return nil, err
 	}
-	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	me, err := srv.userClient.GetMe(ctx, &emptypb.Empty{})
+	me, err := auth.FromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	err = srv.shopStore.UpdateShopName(ctx, repository.UpdateShopNameParams{
-		Name:     req.GetName(),
-		SellerID: me.Id,
+	current, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var old, shop repository.Shop
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		// lock the row so the replaced avatar is the one deleted below
+		old, err = q.GetShopForUpdate(ctx, current.ID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't lock shop: %v", err)
+		}
+
+		shop, err = q.UpdateShopProfile(ctx, profileUpdateParams(me.ID, req.GetProfile(), paths))
+		if err != nil {
+			return status.Errorf(codes.Internal, "Cập nhật thất bại do %v", err)
+		}
+
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Cập nhật thất bại do %v", err)
+		return nil, err
 	}
 
-	return &pb.GetShopResponse{
-		Name: "Cập nhật tên cửa hàng thành công",
-	}, nil
+	// an uploaded avatar replaced or cleared here is no longer referenced
+	if old.Avatar.String != shop.Avatar.String {
+		srv.deleteUploadedAvatar(ctx, shop.ID, old.Avatar.String)
+	}
+
+	return srv.shopProfile(ctx, shop)
 }
 
+// GetShop ...
 func (srv *ShopService) GetShop(ctx context.Context, req *pb.GetShopRequest) (*pb.GetShopResponse, error) {
-	shop, err := srv.shopStore.GetShopByID(ctx, req.GetShopId())
+	var shop repository.Shop
+	var err error
+	switch selector := req.GetSelector().(type) {
+	case *pb.GetShopRequest_ShopId:
+		shop, err = srv.shopStore.GetShopByID(ctx, selector.ShopId)
+	case *pb.GetShopRequest_SellerId:
+		shop, err = srv.shopStore.GetShopBySellerID(ctx, selector.SellerId)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "shop_id or seller_id is required")
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
+	}
 	if err != nil {
-		return &pb.GetShopResponse{
-			Name: "ecommerce official",
-		}, nil
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+	if !shopVisible(shop) {
+		return nil, status.Error(codes.NotFound, "Cửa hàng không tồn tại")
 	}
 
-	return &pb.GetShopResponse{
-		Name: shop.Name,
-	}, nil
+	return srv.shopProfile(ctx, shop)
 }
 
-func (srv *ShopService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
-	// auth
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "can't parse context")
+// shopProfile builds the public profile of a shop, aggregating its cached
+// catalog
+func (srv *ShopService) shopProfile(ctx context.Context, shop repository.Shop) (*pb.GetShopResponse, error) {
+	followerCount, err := srv.shopStore.CountShopFollowers(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't count followers: %v", err)
 	}
-	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	me, err := srv.userClient.GetMe(ctx, &emptypb.Empty{})
+	categoryIDs, err := srv.shopStore.ListShopCategoryIDs(ctx, shop.ID)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "can't list categories: %v", err)
 	}
 
-	_, err = srv.productClient.DeleteProduct(ctx, &pb.DeleteProductRequest{
-		ProductId:  req.ProductId,
-		SupplierId: me.Id,
+	profile := &pb.GetShopResponse{
+		ShopId:        shop.ID,
+		SellerId:      shop.SellerID,
+		Name:          shop.Name,
+		Avatar:        shop.Avatar.String,
+		Description:   shop.Description,
+		CreatedAt:     timestamppb.New(shop.CreatedAt),
+		FollowerCount: followerCount,
+		IsOfficial:    shop.IsOfficial,
+		Banner:        shop.Banner,
+		ContactPhone:  shop.ContactPhone,
+		ContactEmail:  shop.ContactEmail,
+		Address:       shop.Address,
+		CategoryIds:   categoryIDs,
+		Status:        shopStatus(shop.Status),
+		ReviewCount:   shop.ReviewCount,
+	}
+	if shop.ReviewCount > 0 {
+		profile.ReviewRatingAverage = float32(shop.ReviewRatingSum) / float32(shop.ReviewCount)
+	}
+
+	// the profile is still useful without catalog figures, so don't fail
+	// the whole request when product-service is unavailable
+	products, err := srv.cachedSupplierProducts(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId: shop.SellerID,
 	})
+	if err != nil {
+		log.Printf("can't aggregate products of shop %d: %v", shop.ID, err)
+		return profile, nil
+	}
+	summary := summarizeProducts(products)
+	profile.ProductCount = summary.productCount
+	profile.RatingAverage = summary.ratingAverage
+
+	return profile, nil
+}
+
+// sellerShop returns the shop of sellerID
+func (srv *ShopService) sellerShop(ctx context.Context, sellerID int64) (repository.Shop, error) {
+	shop, err := srv.shopStore.GetShopBySellerID(ctx, sellerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return shop, status.Error(codes.NotFound, "Bạn chưa đăng kí cửa hàng")
+	}
+	if err != nil {
+		return shop, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	return shop, nil
+}
+
+func (srv *ShopService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if me.Role == pb.UserRole_admin {
+		_, err = srv.productClient.DeleteProductByAdmin(ctx, &pb.DeleteProductByAdminRequest{
+			ProductId: req.ProductId,
+		})
+	} else {
+		_, err = srv.productClient.DeleteProduct(ctx, &pb.DeleteProductRequest{
+			ProductId:  req.ProductId,
+			SupplierId: me.ID,
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -115,17 +240,13 @@ func (srv *ShopService) DeleteProduct(ctx context.Context, req *pb.DeleteProduct
 
 // UpdateProduct ...
 func (srv *ShopService) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.GeneralResponse, error) {
-	// auth
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "can't parse context")
-	}
-	ctx = metadata.NewOutgoingContext(ctx, md)
-
-	me, err := srv.userClient.GetMe(ctx, &emptypb.Empty{})
+	me, err := auth.FromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
 
 	log.Println("update product: ", req)
 	_, err = srv.productClient.UpdateProduct(ctx, &pb.UpdateProductRequest{
@@ -135,7 +256,7 @@ func (srv *ShopService) UpdateProduct(ctx context.Context, req *pb.UpdateProduct
 		Thumbnail:  req.Thumbnail,
 		Inventory:  req.Inventory,
 		Brand:      req.Brand,
-		SupplierId: me.Id,
+		SupplierId: me.ID,
 	})
 
 	if err != nil {
@@ -150,34 +271,60 @@ func (srv *ShopService) UpdateProduct(ctx context.Context, req *pb.UpdateProduct
 
 // RegisterShop ...
 func (srv *ShopService) RegisterShop(ctx context.Context, req *pb.RegisterShopRequest) (*pb.GeneralResponse, error) {
-	// auth
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "can't parse context")
+	err := validateShopProfile(&pb.ShopProfile{
+		Name:   req.GetName(),
+		Avatar: req.GetAvatar(),
+	}, []string{profileName, profileAvatar})
+	if err != nil {
+		return nil, err
 	}
-	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	// TODO: two phase commit
-	// update user role
-	_, err := srv.userClient.SupplierRegister(ctx, &emptypb.Empty{})
+	me, err := auth.FromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	me, err := srv.userClient.GetMe(ctx, &emptypb.Empty{})
+	categoryIDs, err := srv.validateCategoryIDs(ctx, req.GetCategoryId())
 	if err != nil {
 		return nil, err
 	}
 
-	// create shop
-	err = srv.shopStore.CreateShop(ctx, repository.CreateShopParams{
-		SellerID: me.GetId(),
-		Name:     req.GetName(),
-		Avatar: sql.NullString{
-			String: req.GetAvatar(),
-			Valid:  false,
-		},
-	})
+	_, err = srv.shopStore.GetShopBySellerID(ctx, me.ID)
+	if err == nil {
+		return nil, status.Error(codes.AlreadyExists, "Bạn đã đăng kí cửa hàng")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "can't get shop: %v", err)
+	}
+
+	// resume the seller's unfinished registration, if any, so a retried
+	// request never grants the role or creates the shop twice
+	saga, err := srv.shopStore.GetActiveRegistrationSaga(ctx, me.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		saga, err = srv.shopStore.CreateRegistrationSaga(ctx, repository.CreateRegistrationSagaParams{
+			SellerID: me.ID,
+			Name:     strings.TrimSpace(req.GetName()),
+			Avatar: sql.NullString{
+				String: req.GetAvatar(),
+				Valid:  req.GetAvatar() != "",
+			},
+			CategoryIds: categoryIDs,
+			// suppliers without a shop register one without being granted the
+			// role again
+			RolePreexisting: me.Role == pb.UserRole_supplier,
+		})
+	}
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.Aborted, "Đang xử lý đăng kí cửa hàng, vui lòng thử lại sau")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't start registration: %v", err)
+	}
+
+	err = srv.runRegistrationSaga(ctx, &saga)
+	if errors.Is(err, errSagaConflict) {
+		return nil, status.Error(codes.Aborted, "Đang xử lý đăng kí cửa hàng, vui lòng thử lại sau")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -191,19 +338,17 @@ var _empty = &emptypb.Empty{}
 
 // AddProduct ...
 func (srv *ShopService) AddProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
-	// authorization for supplier or admin
-	md, _ := metadata.FromIncomingContext(ctx)
-	ctx = metadata.NewOutgoingContext(ctx, md)
-
-	claims, err := srv.authClient.GetUserClaims(ctx, _empty)
+	me, err := auth.FromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if claims.GetUserRole() == pb.UserRole_customer {
-		return nil, errors.New("unauthorization request")
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopOpen(ctx, me.ID); err != nil {
+		return nil, err
 	}
-	supplierID, _ := strconv.ParseInt(claims.Id, 10, 64)
-	req.SupplierId = supplierID
+	req.SupplierId = me.ID
 
 	// add product
 	return srv.productClient.CreateProduct(ctx, req)
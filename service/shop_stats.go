@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statsCache keeps the stats of each shop for a while, so refreshing the
+// dashboard doesn't page through the whole catalog every time
+type statsCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[int64]*pb.ShopStats
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{
+		ttl:     ttl,
+		entries: make(map[int64]*pb.ShopStats),
+	}
+}
+
+func (cache *statsCache) get(shopID int64) (*pb.ShopStats, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	stats, ok := cache.entries[shopID]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(stats.GetComputedAt().AsTime()) > cache.ttl {
+		delete(cache.entries, shopID)
+		return nil, false
+	}
+
+	return stats, true
+}
+
+func (cache *statsCache) put(stats *pb.ShopStats) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries[stats.GetShopId()] = stats
+}
+
+// GetShopStats returns the dashboard figures of the caller's shop. They may
+// be up to Config.StatsCacheTTL old, see computed_at.
+func (srv *ShopService) GetShopStats(ctx context.Context, _ *empty.Empty) (*pb.ShopStats, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats, ok := srv.stats.get(shop.ID); ok {
+		return proto.Clone(stats).(*pb.ShopStats), nil
+	}
+
+	stats, err := srv.computeShopStats(ctx, shop)
+	if err != nil {
+		return nil, err
+	}
+	srv.stats.put(stats)
+
+	return proto.Clone(stats).(*pb.ShopStats), nil
+}
+
+func (srv *ShopService) computeShopStats(ctx context.Context, shop repository.Shop) (*pb.ShopStats, error) {
+	followerCount, err := srv.shopStore.CountShopFollowers(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't count followers: %v", err)
+	}
+
+	products, err := srv.listSupplierProducts(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId: shop.SellerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &pb.ShopStats{
+		ShopId:        shop.ID,
+		ProductCount:  int64(len(products)),
+		FollowerCount: followerCount,
+		ComputedAt:    timestamppb.Now(),
+	}
+	for _, product := range products {
+		stats.UnitsSold += product.GetTotalSold()
+		stats.InventoryValue += product.GetPrice() * int64(product.GetInventory())
+		if product.GetInventory() <= 0 {
+			stats.OutOfStockCount++
+		}
+	}
+	stats.RatingAverage = productRatingAverage(products)
+
+	return stats, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"database/sql"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shop profile fields accepted in an update mask
+const (
+	profileName         = "name"
+	profileDescription  = "description"
+	profileAvatar       = "avatar"
+	profileBanner       = "banner"
+	profileContactPhone = "contact_phone"
+	profileContactEmail = "contact_email"
+	profileAddress      = "address"
+)
+
+const (
+	maxShopNameLength    = 64
+	maxDescriptionLength = 2000
+	maxURLLength         = 256
+	maxEmailLength       = 128
+	maxAddressLength     = 256
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+
+// validateShopProfile checks the fields of profile named in paths
+func validateShopProfile(profile *pb.ShopProfile, paths []string) error {
+	for _, path := range paths {
+		if err := validateProfileField(profile, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateShopName checks a shop name configured outside of the API, like
+// the name of the official shop, against the profile rules
+func ValidateShopName(name string) error {
+	return validateShopProfile(&pb.ShopProfile{Name: name}, []string{profileName})
+}
+
+func validateProfileField(profile *pb.ShopProfile, path string) error {
+	switch path {
+	case profileName:
+		name := strings.TrimSpace(profile.GetName())
+		if name == "" {
+			return status.Error(codes.InvalidArgument, "Vui lòng điền tên cửa hàng")
+		}
+		if utf8.RuneCountInString(name) > maxShopNameLength {
+			return status.Errorf(codes.InvalidArgument, "Tên cửa hàng không được dài quá %d kí tự", maxShopNameLength)
+		}
+	case profileDescription:
+		if utf8.RuneCountInString(profile.GetDescription()) > maxDescriptionLength {
+			return status.Errorf(codes.InvalidArgument, "Mô tả không được dài quá %d kí tự", maxDescriptionLength)
+		}
+	case profileAvatar:
+		return validateImageURL(profile.GetAvatar(), "Ảnh đại diện")
+	case profileBanner:
+		return validateImageURL(profile.GetBanner(), "Ảnh bìa")
+	case profileContactPhone:
+		if phone := profile.GetContactPhone(); phone != "" && !phonePattern.MatchString(phone) {
+			return status.Error(codes.InvalidArgument, "Số điện thoại không hợp lệ")
+		}
+	case profileContactEmail:
+		email := profile.GetContactEmail()
+		if email == "" {
+			return nil
+		}
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email || len(email) > maxEmailLength {
+			return status.Error(codes.InvalidArgument, "Email không hợp lệ")
+		}
+	case profileAddress:
+		if utf8.RuneCountInString(profile.GetAddress()) > maxAddressLength {
+			return status.Errorf(codes.InvalidArgument, "Địa chỉ không được dài quá %d kí tự", maxAddressLength)
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown profile field %q", path)
+	}
+
+	return nil
+}
+
+// validateImageURL accepts an empty value, which clears the image
+func validateImageURL(raw string, field string) error {
+	if raw == "" {
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(raw) > maxURLLength {
+		return status.Errorf(codes.InvalidArgument, "%s không hợp lệ", field)
+	}
+
+	return nil
+}
+
+// profileUpdateParams sets the columns named in paths, leaving the others
+// untouched
+func profileUpdateParams(sellerID int64, profile *pb.ShopProfile, paths []string) repository.UpdateShopProfileParams {
+	arg := repository.UpdateShopProfileParams{
+		SellerID: sellerID,
+	}
+	for _, path := range paths {
+		switch path {
+		case profileName:
+			arg.Name = nullString(strings.TrimSpace(profile.GetName()))
+		case profileDescription:
+			arg.Description = nullString(profile.GetDescription())
+		case profileAvatar:
+			arg.Avatar = nullString(profile.GetAvatar())
+		case profileBanner:
+			arg.Banner = nullString(profile.GetBanner())
+		case profileContactPhone:
+			arg.ContactPhone = nullString(profile.GetContactPhone())
+		case profileContactEmail:
+			arg.ContactEmail = nullString(profile.GetContactEmail())
+		case profileAddress:
+			arg.Address = nullString(profile.GetAddress())
+		}
+	}
+
+	return arg
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  true,
+	}
+}
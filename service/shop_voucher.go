@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var voucherCodePattern = regexp.MustCompile(`^[A-Z0-9]{4,32}$`)
+
+const maxCartLines = 100
+
+// CreateVoucher adds a voucher to the caller's shop
+func (srv *ShopService) CreateVoucher(ctx context.Context, req *pb.CreateVoucherRequest) (*pb.Voucher, error) {
+	code := normalizeVoucherCode(req.GetCode())
+	if !voucherCodePattern.MatchString(code) {
+		return nil, status.Error(codes.InvalidArgument, "Mã giảm giá gồm 4 đến 32 chữ cái hoặc chữ số")
+	}
+	if err := validateVoucher(req); err != nil {
+		return nil, err
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.checkShopActive(ctx, me.ID); err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err := srv.shopStore.CreateShopVoucher(ctx, repository.CreateShopVoucherParams{
+		ShopID:        shop.ID,
+		Code:          code,
+		Kind:          req.GetKind().String(),
+		Value:         req.GetValue(),
+		MinOrderValue: req.GetMinOrderValue(),
+		UsageLimit:    req.GetUsageLimit(),
+		PerUserLimit:  req.GetPerUserLimit(),
+		StartsAt:      req.GetStartsAt().AsTime(),
+		EndsAt:        req.GetEndsAt().AsTime(),
+	})
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "Mã giảm giá đã tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't create voucher: %v", err)
+	}
+
+	return shopVoucher(voucher), nil
+}
+
+// ListShopVouchers returns the vouchers of a shop that can be used now,
+// newest first
+func (srv *ShopService) ListShopVouchers(ctx context.Context, req *pb.ListShopVouchersRequest) (*pb.ListShopVouchersResponse, error) {
+	if _, err := srv.visibleShop(ctx, req.GetShopId()); err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(req.GetLimit())
+	vouchers, err := srv.shopStore.ListActiveShopVouchers(ctx, repository.ListActiveShopVouchersParams{
+		ShopID:   req.GetShopId(),
+		Cursor:   req.GetCursor(),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list vouchers: %v", err)
+	}
+
+	resp := &pb.ListShopVouchersResponse{
+		Vouchers: make([]*pb.Voucher, 0, len(vouchers)),
+	}
+	for _, voucher := range vouchers {
+		resp.Vouchers = append(resp.Vouchers, shopVoucher(voucher))
+	}
+	if len(vouchers) == int(limit) {
+		resp.NextCursor = vouchers[len(vouchers)-1].ID
+	}
+
+	return resp, nil
+}
+
+// ValidateVoucher quotes the discount a voucher gives the caller on a cart
+// without using it up
+func (srv *ShopService) ValidateVoucher(ctx context.Context, req *pb.ValidateVoucherRequest) (*pb.VoucherQuote, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.visibleShop(ctx, req.GetShopId())
+	if err != nil {
+		return nil, err
+	}
+	subtotal, err := srv.cartSubtotal(ctx, shop, req.GetLines())
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err := srv.shopStore.GetShopVoucherByCode(ctx, repository.GetShopVoucherByCodeParams{
+		ShopID: shop.ID,
+		Code:   normalizeVoucherCode(req.GetCode()),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "Mã giảm giá không tồn tại")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get voucher: %v", err)
+	}
+
+	return quoteVoucher(ctx, srv.shopStore.Queries, voucher, me.ID, subtotal)
+}
+
+// RedeemVoucher uses a voucher for an order. The voucher row is locked for
+// the redemption, so parallel checkouts can't exceed its caps.
+//
+// order_id isn't checked against order-service, so a caller could use up the
+// voucher with made up orders. The policy table only lets order-service call
+// it, with the service token, acting for the buyer.
+func (srv *ShopService) RedeemVoucher(ctx context.Context, req *pb.RedeemVoucherRequest) (*pb.VoucherQuote, error) {
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.visibleShop(ctx, req.GetShopId())
+	if err != nil {
+		return nil, err
+	}
+	subtotal, err := srv.cartSubtotal(ctx, shop, req.GetLines())
+	if err != nil {
+		return nil, err
+	}
+
+	var quote *pb.VoucherQuote
+	err = srv.shopStore.ExecTx(ctx, func(q *repository.Queries) error {
+		voucher, err := q.GetShopVoucherByCodeForUpdate(ctx, repository.GetShopVoucherByCodeForUpdateParams{
+			ShopID: shop.ID,
+			Code:   normalizeVoucherCode(req.GetCode()),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "Mã giảm giá không tồn tại")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't get voucher: %v", err)
+		}
+
+		redemption, err := q.GetVoucherRedemptionByOrder(ctx, repository.GetVoucherRedemptionByOrderParams{
+			VoucherID: voucher.ID,
+			OrderID:   req.GetOrderId(),
+		})
+		if err == nil {
+			if redemption.UserID != me.ID {
+				return status.Error(codes.AlreadyExists, "Đơn hàng đã dùng mã giảm giá này")
+			}
+			quote = voucherQuote(voucher, redemption.Subtotal, redemption.Discount)
+			quote.RedemptionId = redemption.ID
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.Internal, "can't get redemption: %v", err)
+		}
+
+		quote, err = quoteVoucher(ctx, q, voucher, me.ID, subtotal)
+		if err != nil {
+			return err
+		}
+
+		used, err := q.UseShopVoucher(ctx, voucher.ID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't use voucher: %v", err)
+		}
+		if used == 0 {
+			return status.Error(codes.FailedPrecondition, "Mã giảm giá đã hết lượt sử dụng")
+		}
+
+		redemption, err = q.CreateVoucherRedemption(ctx, repository.CreateVoucherRedemptionParams{
+			VoucherID: voucher.ID,
+			UserID:    me.ID,
+			OrderID:   req.GetOrderId(),
+			Subtotal:  quote.GetSubtotal(),
+			Discount:  quote.GetDiscount(),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't record redemption: %v", err)
+		}
+		quote.Voucher.UsedCount++
+		quote.RedemptionId = redemption.ID
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// quoteVoucher checks userID may use voucher on an order of subtotal and
+// works out the discount
+func quoteVoucher(ctx context.Context, q *repository.Queries, voucher repository.ShopVoucher, userID int64, subtotal int64) (*pb.VoucherQuote, error) {
+	if subtotal <= 0 {
+		return nil, status.Error(codes.FailedPrecondition, "Giỏ hàng không có sản phẩm nào của cửa hàng")
+	}
+
+	now := time.Now()
+	if now.Before(voucher.StartsAt) {
+		return nil, status.Error(codes.FailedPrecondition, "Mã giảm giá chưa đến thời gian sử dụng")
+	}
+	if !now.Before(voucher.EndsAt) {
+		return nil, status.Error(codes.FailedPrecondition, "Mã giảm giá đã hết hạn")
+	}
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return nil, status.Error(codes.FailedPrecondition, "Mã giảm giá đã hết lượt sử dụng")
+	}
+	if subtotal < voucher.MinOrderValue {
+		return nil, status.Errorf(codes.FailedPrecondition, "Đơn hàng tối thiểu %d để dùng mã giảm giá này", voucher.MinOrderValue)
+	}
+
+	if voucher.PerUserLimit > 0 {
+		used, err := q.CountUserVoucherRedemptions(ctx, repository.CountUserVoucherRedemptionsParams{
+			VoucherID: voucher.ID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't count redemptions: %v", err)
+		}
+		if used >= int64(voucher.PerUserLimit) {
+			return nil, status.Error(codes.FailedPrecondition, "Bạn đã dùng hết lượt của mã giảm giá này")
+		}
+	}
+
+	var discount int64
+	if voucher.Kind == pb.VoucherKind_percentage.String() {
+		discount = subtotal * voucher.Value / 100
+	} else {
+		discount = voucher.Value
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+
+	return voucherQuote(voucher, subtotal, discount), nil
+}
+
+// cartSubtotal sums up the value of the lines sold by shop, lines of other
+// shops don't count toward its vouchers
+func (srv *ShopService) cartSubtotal(ctx context.Context, shop repository.Shop, lines []*pb.CartLine) (int64, error) {
+	if len(lines) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "lines is required")
+	}
+	if len(lines) > maxCartLines {
+		return 0, status.Errorf(codes.InvalidArgument, "a cart has at most %d lines", maxCartLines)
+	}
+
+	quantities := make(map[int64]int64, len(lines))
+	productIDs := make([]int64, 0, len(lines))
+	for _, line := range lines {
+		if line.GetQuantity() <= 0 {
+			return 0, status.Error(codes.InvalidArgument, "Số lượng sản phẩm phải lớn hơn 0")
+		}
+		if _, ok := quantities[line.GetProductId()]; !ok {
+			productIDs = append(productIDs, line.GetProductId())
+		}
+		quantities[line.GetProductId()] += int64(line.GetQuantity())
+	}
+
+	products, err := srv.productsByID(ctx, productIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	var subtotal int64
+	for _, id := range productIDs {
+		product, ok := products[id]
+		if !ok {
+			return 0, status.Errorf(codes.InvalidArgument, "Sản phẩm %d không tồn tại", id)
+		}
+		if product.GetSupplierId() == shop.SellerID {
+			subtotal += product.GetPrice() * quantities[id]
+		}
+	}
+
+	return subtotal, nil
+}
+
+func validateVoucher(req *pb.CreateVoucherRequest) error {
+	switch req.GetKind() {
+	case pb.VoucherKind_percentage:
+		if req.GetValue() < 1 || req.GetValue() > 100 {
+			return status.Error(codes.InvalidArgument, "Phần trăm giảm giá phải từ 1 đến 100")
+		}
+	case pb.VoucherKind_fixed_amount:
+		if req.GetValue() <= 0 {
+			return status.Error(codes.InvalidArgument, "Số tiền giảm phải lớn hơn 0")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown voucher kind %v", req.GetKind())
+	}
+
+	if req.GetMinOrderValue() < 0 || req.GetUsageLimit() < 0 || req.GetPerUserLimit() < 0 {
+		return status.Error(codes.InvalidArgument, "min_order_value, usage_limit and per_user_limit can't be negative")
+	}
+	if req.GetStartsAt() == nil || req.GetEndsAt() == nil {
+		return status.Error(codes.InvalidArgument, "starts_at and ends_at are required")
+	}
+	if !req.GetEndsAt().AsTime().After(req.GetStartsAt().AsTime()) {
+		return status.Error(codes.InvalidArgument, "Thời gian kết thúc phải sau thời gian bắt đầu")
+	}
+	if !req.GetEndsAt().AsTime().After(time.Now()) {
+		return status.Error(codes.InvalidArgument, "Thời gian kết thúc phải ở trong tương lai")
+	}
+
+	return nil
+}
+
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func voucherQuote(voucher repository.ShopVoucher, subtotal int64, discount int64) *pb.VoucherQuote {
+	return &pb.VoucherQuote{
+		Voucher:  shopVoucher(voucher),
+		Subtotal: subtotal,
+		Discount: discount,
+		Total:    subtotal - discount,
+	}
+}
+
+func shopVoucher(voucher repository.ShopVoucher) *pb.Voucher {
+	return &pb.Voucher{
+		Id:            voucher.ID,
+		ShopId:        voucher.ShopID,
+		Code:          voucher.Code,
+		Kind:          pb.VoucherKind(pb.VoucherKind_value[voucher.Kind]),
+		Value:         voucher.Value,
+		MinOrderValue: voucher.MinOrderValue,
+		UsageLimit:    voucher.UsageLimit,
+		PerUserLimit:  voucher.PerUserLimit,
+		UsedCount:     voucher.UsedCount,
+		StartsAt:      timestamppb.New(voucher.StartsAt),
+		EndsAt:        timestamppb.New(voucher.EndsAt),
+	}
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/e-commerce-microservices/shop-service/auth"
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"github.com/e-commerce-microservices/shop-service/repository"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// how often WatchStockAlerts looks for new alerts
+	stockAlertPollInterval = 5 * time.Second
+	stockAlertBatch        = 100
+)
+
+// SetStockThreshold sets the low-stock threshold of a product, or the
+// default of every product of the shop when product_id is 0
+func (srv *ShopService) SetStockThreshold(ctx context.Context, req *pb.SetStockThresholdRequest) (*pb.StockThreshold, error) {
+	if req.GetThreshold() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Ngưỡng tồn kho không được âm")
+	}
+
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetProductId() != 0 {
+		if _, err := srv.ownProduct(ctx, me.ID, req.GetProductId()); err != nil {
+			return nil, err
+		}
+	}
+
+	threshold, err := srv.shopStore.SetStockThreshold(ctx, repository.SetStockThresholdParams{
+		ShopID:    shop.ID,
+		ProductID: req.GetProductId(),
+		Threshold: req.GetThreshold(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't set threshold: %v", err)
+	}
+
+	return &pb.StockThreshold{
+		ProductId: threshold.ProductID,
+		Threshold: threshold.Threshold,
+	}, nil
+}
+
+// ListStockThresholds returns the thresholds set by the caller
+func (srv *ShopService) ListStockThresholds(ctx context.Context, _ *empty.Empty) (*pb.ListStockThresholdsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := srv.shopStore.ListStockThresholds(ctx, shop.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list thresholds: %v", err)
+	}
+
+	resp := &pb.ListStockThresholdsResponse{
+		Thresholds: make([]*pb.StockThreshold, 0, len(thresholds)),
+	}
+	for _, threshold := range thresholds {
+		resp.Thresholds = append(resp.Thresholds, &pb.StockThreshold{
+			ProductId: threshold.ProductID,
+			Threshold: threshold.Threshold,
+		})
+	}
+
+	return resp, nil
+}
+
+// ListStockAlerts returns the alerts of the caller's shop, newest first
+func (srv *ShopService) ListStockAlerts(ctx context.Context, req *pb.ListStockAlertsRequest) (*pb.ListStockAlertsResponse, error) {
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(req.GetLimit())
+	alerts, err := srv.shopStore.ListStockAlerts(ctx, repository.ListStockAlertsParams{
+		ShopID:          shop.ID,
+		IncludeResolved: req.GetIncludeResolved(),
+		Cursor:          req.GetCursor(),
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't list alerts: %v", err)
+	}
+
+	resp := &pb.ListStockAlertsResponse{
+		Alerts: make([]*pb.StockAlert, 0, len(alerts)),
+	}
+	for _, alert := range alerts {
+		resp.Alerts = append(resp.Alerts, stockAlert(alert))
+	}
+	if len(alerts) == int(limit) {
+		resp.NextCursor = alerts[len(alerts)-1].ID
+	}
+
+	return resp, nil
+}
+
+// WatchStockAlerts streams the open alerts of the caller's shop, oldest
+// first, then keeps streaming new ones until the client goes away. Alerts
+// are read back from the database, so it sees the alerts recorded by every
+// replica.
+func (srv *ShopService) WatchStockAlerts(req *pb.WatchStockAlertsRequest, stream pb.ShopService_WatchStockAlertsServer) error {
+	ctx := stream.Context()
+	me, err := auth.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	shop, err := srv.sellerShop(ctx, me.ID)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(stockAlertPollInterval)
+	defer ticker.Stop()
+
+	afterID := req.GetAfterId()
+	for {
+		alerts, err := srv.shopStore.ListOpenStockAlertsAfter(ctx, repository.ListOpenStockAlertsAfterParams{
+			ShopID:   shop.ID,
+			AfterID:  afterID,
+			RowLimit: stockAlertBatch,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "can't list alerts: %v", err)
+		}
+		for _, alert := range alerts {
+			if err := stream.Send(stockAlert(alert)); err != nil {
+				return err
+			}
+			afterID = alert.ID
+		}
+		if len(alerts) == stockAlertBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckStockLevels compares the stock of the products of every shop with a
+// threshold to it on an interval, recording an alert when a product runs low
+// and resolving it once the product is restocked. It returns when ctx is
+// done.
+func (srv *ShopService) CheckStockLevels(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		srv.checkStockLevels(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *ShopService) checkStockLevels(ctx context.Context) {
+	shops, err := srv.shopStore.ListStockWatchedShops(ctx)
+	if err != nil {
+		log.Println("can't list shops to check stock: ", err)
+		return
+	}
+
+	for _, shop := range shops {
+		if err := srv.checkShopStock(ctx, shop); err != nil {
+			log.Printf("check stock of shop %d: %v", shop.ID, err)
+		}
+	}
+}
+
+func (srv *ShopService) checkShopStock(ctx context.Context, shop repository.Shop) error {
+	thresholds, err := srv.shopStore.ListStockThresholds(ctx, shop.ID)
+	if err != nil {
+		return err
+	}
+	byProduct := make(map[int64]int32, len(thresholds))
+	for _, threshold := range thresholds {
+		byProduct[threshold.ProductID] = threshold.Threshold
+	}
+
+	products, err := srv.listSupplierProducts(ctx, &pb.GetProductBySupplierRequest{
+		SupplierId: shop.SellerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		threshold, ok := byProduct[product.GetProductId()]
+		if !ok {
+			threshold = byProduct[0]
+		}
+
+		if product.GetInventory() < threshold {
+			_, err = srv.shopStore.CreateStockAlert(ctx, repository.CreateStockAlertParams{
+				ShopID:      shop.ID,
+				ProductID:   product.GetProductId(),
+				ProductName: product.GetName(),
+				Inventory:   int64(product.GetInventory()),
+				Threshold:   threshold,
+			})
+		} else {
+			err = srv.shopStore.ResolveStockAlert(ctx, product.GetProductId())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func stockAlert(alert repository.StockAlert) *pb.StockAlert {
+	resp := &pb.StockAlert{
+		Id:          alert.ID,
+		ProductId:   alert.ProductID,
+		ProductName: alert.ProductName,
+		Inventory:   alert.Inventory,
+		Threshold:   alert.Threshold,
+		Resolved:    alert.Resolved,
+		CreatedAt:   timestamppb.New(alert.CreatedAt),
+	}
+	if alert.ResolvedAt.Valid {
+		resp.ResolvedAt = timestamppb.New(alert.ResolvedAt.Time)
+	}
+
+	return resp
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/e-commerce-microservices/shop-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	supplierProductPageSize = 100
+	// guards against a product-service that ignores the offset
+	supplierProductMaxPages = 100
+)
+
+// listSupplierProducts pages through every product of query.SupplierId in the
+// order set by its sort flags; query.Limit and query.Offset are ignored. A
+// catalog longer than supplierProductMaxPages pages is an error rather than
+// cut short.
+func (srv *ShopService) listSupplierProducts(ctx context.Context, query *pb.GetProductBySupplierRequest) ([]*pb.Product, error) {
+	var products []*pb.Product
+	for page := 0; ; page++ {
+		if page == supplierProductMaxPages {
+			return nil, status.Errorf(codes.FailedPrecondition, "catalog of supplier %d has more than %d products", query.GetSupplierId(), supplierProductMaxPages*supplierProductPageSize)
+		}
+
+		resp, err := srv.productClient.GetProductBySupplier(ctx, &pb.GetProductBySupplierRequest{
+			SupplierId:  query.GetSupplierId(),
+			Limit:       supplierProductPageSize,
+			Offset:      int32(page * supplierProductPageSize),
+			ByTime:      query.GetByTime(),
+			ByPriceInc:  query.GetByPriceInc(),
+			ByPriceDesc: query.GetByPriceDesc(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, resp.GetListProduct()...)
+		if len(resp.GetListProduct()) < supplierProductPageSize {
+			break
+		}
+	}
+
+	return products, nil
+}
+
+// productSummary aggregates the catalog of a shop
+type productSummary struct {
+	productCount  int64
+	ratingAverage float32
+}
+
+func summarizeProducts(products []*pb.Product) productSummary {
+	return productSummary{
+		productCount:  int64(len(products)),
+		ratingAverage: productRatingAverage(products),
+	}
+}
+
+// productRatingAverage averages the star ratings of products weighted by
+// units sold, so a product rated once doesn't count as much as a best
+// seller. Products nobody has rated yet report a zero star average, they are
+// left out instead of dragging the rating down.
+func productRatingAverage(products []*pb.Product) float32 {
+	var weightedSum, sum float64
+	var weight int64
+	var rated int
+	for _, product := range products {
+		if product.GetStarAverage() <= 0 {
+			continue
+		}
+		weightedSum += float64(product.GetStarAverage()) * float64(product.GetTotalSold())
+		weight += product.GetTotalSold()
+		sum += float64(product.GetStarAverage())
+		rated++
+	}
+
+	switch {
+	case weight > 0:
+		return float32(weightedSum / float64(weight))
+	case rated > 0:
+		// rated products that never sold, weigh them equally
+		return float32(sum / float64(rated))
+	}
+	return 0
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidKey is returned for keys escaping the store, e.g. "../x"
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore saves binary objects such as shop avatars and hands back the
+// public URL they are served from
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs on the local filesystem, for development and tests
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStore stores blobs under root and builds their URLs from baseURL
+func NewLocalStore(root string, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Put ...
+func (store *LocalStore) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	file, err := store.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", err
+	}
+
+	// write to a temp file first so readers never see a partial blob
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	return store.baseURL + "/" + key, nil
+}
+
+// Delete ...
+func (store *LocalStore) Delete(ctx context.Context, key string) error {
+	file, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Handler serves the stored blobs over http
+func (store *LocalStore) Handler() http.Handler {
+	return http.FileServer(http.Dir(store.root))
+}
+
+func (store *LocalStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(store.root, filepath.FromSlash(clean)), nil
+}